@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/config"
+	"github.com/gh-standup/internal/llm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var flagProfile string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage default flag values and profiles",
+	Long: fmt.Sprintf(`Manage the configuration file, %s in the gh config directory.
+
+Settings are named after flags and apply to every run; settings in the profile
+selected with --profile, or the file's default profile, override them. A
+repository's .github/%s overrides the user's file, but cannot set the
+provider, base URL or files to read, such as prompt-file or holidays. Flags
+take precedence over environment variables such as GH_STANDUP_MODEL, which
+take precedence over the configuration.`, config.FileName, config.FileName),
+	// Configuration commands work on the files, not with the values from them
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <setting>",
+	Short: "Print a setting in effect",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>...",
+	Short: "Set a setting in the user's configuration, or in a profile with --profile",
+	Long: `Set a setting in the user's configuration file, or in a profile with --profile.
+Settings for flags that can be repeated, such as repo or org, take several
+values, given as separate arguments or separated by commas where the flag
+accepts that. The "profile" setting selects the default profile.`,
+	Example: `  gh standup config set --profile work org acme
+  gh standup config set repo acme/api,acme/web
+  gh standup config set prompts "system:Be brief" "user:Summarize"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runConfigSet,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print every setting and profile",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&flagProfile, "profile", "", "Configuration profile to use (see gh standup config)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyConfig(cmd)
+	}
+
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// repoSettings are the settings a repository's configuration may set. A
+// cloned repository is not trusted with settings that choose where requests
+// and credentials are sent or which local files are read.
+var repoSettings = map[string]bool{
+	"repo": true, "org": true, "exclude-repo": true, "user": true,
+	"days": true, "since": true, "until": true, "since-last-workday": true,
+	"workdays": true, "standup-time": true, "timezone": true,
+	"backend": true, "sources": true, "concurrency": true, "source-timeout": true,
+	"format": true, "raw": true, "stats": true, "no-stream": true,
+	"model": true, "compare-models": true, "prompts": true,
+	"temperature": true, "top-p": true, "max-tokens": true, "seed": true, "stop": true,
+	"presence-penalty": true, "frequency-penalty": true, "response-format": true,
+	"max-attempts": true, "max-retry-wait": true,
+}
+
+// loadConfig loads the user's configuration, overridden by the current
+// repository's. Settings the repository may not set are ignored.
+func loadConfig() (*config.File, error) {
+	user, err := config.Load(config.UserPath())
+	if err != nil {
+		return nil, err
+	}
+	files := []*config.File{user}
+
+	if wd, err := os.Getwd(); err == nil {
+		if root := llm.FindRepoRoot(wd); root != "" {
+			repoPath := filepath.Join(root, ".github", config.FileName)
+			repo, err := config.Load(repoPath)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range repo.Restrict(repoSettings) {
+				log.Printf("  ⚠️  Ignoring setting %q in %s; only the user's configuration can set it\n", name, repoPath)
+			}
+			files = append(files, repo)
+		}
+	}
+
+	return config.Merge(files...), nil
+}
+
+// activeProfile returns the profile selected by --profile or GH_STANDUP_PROFILE.
+func activeProfile() string {
+	if flagProfile != "" {
+		return flagProfile
+	}
+	return os.Getenv(config.EnvName("profile"))
+}
+
+// flagOrigin is where the value of a flag comes from, in increasing order of
+// precedence.
+type flagOrigin int
+
+const (
+	originDefault flagOrigin = iota
+	originConfig
+	originEnv
+	originCommandLine
+)
+
+// configuredFlags records the flags applyConfig set from the environment or
+// the configuration. They are not marked as changed, so that cobra only
+// checks the flags given on the command line against each other.
+var configuredFlags = map[string]flagOrigin{}
+
+// originOf returns where the value of the named flag comes from.
+func originOf(cmd *cobra.Command, name string) flagOrigin {
+	if cmd.Flags().Changed(name) {
+		return originCommandLine
+	}
+	return configuredFlags[name]
+}
+
+// isSet reports whether the named flag was given a value anywhere, rather
+// than keeping its default.
+func isSet(cmd *cobra.Command, name string) bool {
+	return originOf(cmd, name) != originDefault
+}
+
+// preferFlag returns which of two flags that cannot be combined takes effect
+// when both are set: the one from the source with the higher precedence.
+func preferFlag(cmd *cobra.Command, a, b string) (string, error) {
+	originA, originB := originOf(cmd, a), originOf(cmd, b)
+	switch {
+	case originA > originB:
+		return a, nil
+	case originB > originA:
+		return b, nil
+	}
+	return "", fmt.Errorf("--%s cannot be combined with --%s", a, b)
+}
+
+// applyConfig sets the flags of cmd that were not given on the command line
+// from the environment or, failing that, from the configuration.
+func applyConfig(cmd *cobra.Command) error {
+	file, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	settings, err := file.Resolve(activeProfile())
+	if err != nil {
+		return err
+	}
+	for name := range settings {
+		if name == "profile" || lookupFlag(name) == nil {
+			log.Printf("  ⚠️  Ignoring unknown setting %q in the configuration\n", name)
+		}
+	}
+
+	var errs []string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || flag.Name == "profile" || flag.Name == "help" {
+			return
+		}
+
+		var values []string
+		origin := originEnv
+		source := "environment variable " + config.EnvName(flag.Name)
+		if value, ok := os.LookupEnv(config.EnvName(flag.Name)); ok {
+			values = []string{value}
+		} else if value, ok := settings[flag.Name]; ok {
+			values = config.Values(value)
+			origin = originConfig
+			source = "configuration"
+		}
+		if len(values) == 0 {
+			return
+		}
+
+		for _, value := range values {
+			if err := cmd.Flags().Set(flag.Name, value); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid %s from the %s: %v", flag.Name, source, err))
+				return
+			}
+		}
+		flag.Changed = false
+		configuredFlags[flag.Name] = origin
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// lookupFlag finds a flag of any command by name.
+func lookupFlag(name string) *pflag.Flag {
+	if flag := rootCmd.PersistentFlags().Lookup(name); flag != nil {
+		return flag
+	}
+	if flag := rootCmd.Flags().Lookup(name); flag != nil {
+		return flag
+	}
+	for _, cmd := range rootCmd.Commands() {
+		if flag := cmd.Flags().Lookup(name); flag != nil {
+			return flag
+		}
+	}
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	file, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if name == "profile" {
+		if file.Profile == "" {
+			return fmt.Errorf("no default profile is set")
+		}
+		fmt.Println(file.Profile)
+		return nil
+	}
+
+	settings, err := file.Resolve(activeProfile())
+	if err != nil {
+		return err
+	}
+	value, ok := settings[name]
+	if !ok {
+		return fmt.Errorf("%s is not set", name)
+	}
+	fmt.Println(strings.Join(config.Values(value), ","))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	name, values := args[0], args[1:]
+	var value interface{} = values[0]
+	if name == "profile" {
+		if len(values) > 1 {
+			return fmt.Errorf("the profile setting takes a single value")
+		}
+	} else {
+		flag := lookupFlag(name)
+		if flag == nil || name == "help" {
+			return fmt.Errorf("unknown setting %q (settings are named after flags, such as model or repo)", name)
+		}
+		if _, ok := flag.Value.(pflag.SliceValue); ok {
+			value = listSetting(flag, values)
+		} else if len(values) > 1 {
+			return fmt.Errorf("%s takes a single value", name)
+		}
+		for _, item := range config.Values(value) {
+			if err := validateSetting(flag, item); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", name, err)
+			}
+		}
+	}
+
+	// Only the user's file is written; a repository's file is edited by hand
+	path := config.UserPath()
+	file, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case name == "profile":
+		file.Profile = values[0]
+	case flagProfile != "":
+		if file.Profiles == nil {
+			file.Profiles = map[string]config.Settings{}
+		}
+		if file.Profiles[flagProfile] == nil {
+			file.Profiles[flagProfile] = config.Settings{}
+		}
+		file.Profiles[flagProfile][name] = value
+	default:
+		if file.Settings == nil {
+			file.Settings = config.Settings{}
+		}
+		file.Settings[name] = value
+	}
+
+	return file.Save(path)
+}
+
+// listSetting returns the values of a flag that can be repeated as a list
+// setting. Flags that accept comma-separated values have them split.
+func listSetting(flag *pflag.Flag, values []string) []interface{} {
+	var list []interface{}
+	for _, value := range values {
+		if flag.Value.Type() != "stringSlice" {
+			list = append(list, value)
+			continue
+		}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// validateSetting checks that value parses as the flag's type.
+func validateSetting(flag *pflag.Flag, value string) error {
+	var err error
+	switch flag.Value.Type() {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	return err
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	file, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if file.Profile != "" {
+		fmt.Printf("profile=%s\n", file.Profile)
+	}
+	printSettings("", file.Settings)
+	for _, name := range file.ProfileNames() {
+		printSettings("profiles."+name+".", file.Profiles[name])
+	}
+	return nil
+}
+
+// printSettings prints settings as sorted key=value lines.
+func printSettings(prefix string, settings config.Settings) {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s%s=%s\n", prefix, name, strings.Join(config.Values(settings[name]), ","))
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/config"
+	"github.com/spf13/pflag"
+)
+
+// setupConfig writes content as the user's configuration in a temporary
+// config directory, and runs the test outside any repository.
+func setupConfig(t *testing.T, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "GH_STANDUP_") {
+			t.Setenv(name, "")
+			os.Unsetenv(name)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, config.FileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Cleanup(resetFlags)
+}
+
+// resetFlags restores every flag of the root command to its default.
+func resetFlags() {
+	rootCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			var values []string
+			if defaults := strings.Trim(flag.DefValue, "[]"); defaults != "" {
+				values = strings.Split(defaults, ",")
+			}
+			slice.Replace(values)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+	configuredFlags = map[string]flagOrigin{}
+}
+
+// parseArgs parses args and applies the configuration as a run would.
+func parseArgs(t *testing.T, args ...string) error {
+	t.Helper()
+	if err := rootCmd.ParseFlags(args); err != nil {
+		t.Fatalf("Failed to parse %q: %v", args, err)
+	}
+	if err := applyConfig(rootCmd); err != nil {
+		return err
+	}
+	return rootCmd.ValidateFlagGroups()
+}
+
+func TestConfigDaysWithSinceFlag(t *testing.T) {
+	setupConfig(t, "days: 2\n")
+
+	if err := parseArgs(t, "--since", "2026-10-12"); err != nil {
+		t.Fatalf("Expected --since to override the configured days, got %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	startDate, _, err := reportPeriod(rootCmd, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local); !startDate.Equal(want) {
+		t.Errorf("Expected the period to start at %v, got %v", want, startDate)
+	}
+}
+
+func TestEnvDaysWithSinceFlag(t *testing.T) {
+	setupConfig(t, "")
+	t.Setenv("GH_STANDUP_DAYS", "2")
+
+	if err := parseArgs(t, "--since", "2026-10-12"); err != nil {
+		t.Fatalf("Expected --since to override GH_STANDUP_DAYS, got %v", err)
+	}
+}
+
+func TestConfigDays(t *testing.T) {
+	setupConfig(t, "days: 3\n")
+
+	if err := parseArgs(t); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	startDate, _, err := reportPeriod(rootCmd, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := now.AddDate(0, 0, -3); !startDate.Equal(want) {
+		t.Errorf("Expected the configured days instead of the last workday, got %v", startDate)
+	}
+}
+
+func TestConfigConflictsYieldToFlags(t *testing.T) {
+	setupConfig(t, "model: openai/gpt-4o\nformat: json\n")
+
+	if err := parseArgs(t, "--compare-models", "openai/gpt-4o,openai/gpt-5"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	format, err := outputFormat(rootCmd)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format, err = resolveCompareModels(rootCmd, format); err != nil {
+		t.Fatalf("Expected --compare-models to override the configured model and format, got %v", err)
+	}
+	if format != formatReport || len(flagCompareModels) != 2 {
+		t.Errorf("Expected a report with each model, got %q with %q", format, flagCompareModels)
+	}
+}
+
+func TestConfigFormatWithRawFlag(t *testing.T) {
+	setupConfig(t, "format: csv\n")
+
+	if err := parseArgs(t, "--raw"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format, err := outputFormat(rootCmd); err != nil || format != "json" {
+		t.Errorf("Expected --raw to override the configured format, got %q, %v", format, err)
+	}
+}
+
+func TestConfigConflictWithinSameSource(t *testing.T) {
+	setupConfig(t, "days: 2\nsince: yesterday\n")
+
+	if err := parseArgs(t); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := reportPeriod(rootCmd, time.Now()); err == nil {
+		t.Error("Expected error for days and since in the same configuration")
+	}
+}
+
+func TestConfigSetLists(t *testing.T) {
+	setupConfig(t, "")
+	flagProfile = "work"
+	t.Cleanup(func() { flagProfile = "" })
+
+	for _, args := range [][]string{
+		{"repo", "acme/api,acme/web", "acme/*-service"},
+		{"org", "acme"},
+		{"prompts", "system:Be brief, please", "user:Summarize"},
+		{"days", "3"},
+	} {
+		if err := runConfigSet(configSetCmd, args); err != nil {
+			t.Fatalf("Unexpected error setting %q: %v", args, err)
+		}
+	}
+
+	file, err := config.Load(config.UserPath())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	settings := file.Profiles["work"]
+	if got := config.Values(settings["repo"]); !reflect.DeepEqual(got, []string{"acme/api", "acme/web", "acme/*-service"}) {
+		t.Errorf("Expected comma-separated and repeated repos, got %q", got)
+	}
+	if got := config.Values(settings["org"]); !reflect.DeepEqual(got, []string{"acme"}) {
+		t.Errorf("Expected a list of orgs, got %q", got)
+	}
+	if got := config.Values(settings["prompts"]); !reflect.DeepEqual(got, []string{"system:Be brief, please", "user:Summarize"}) {
+		t.Errorf("Expected prompts not to be split on commas, got %q", got)
+	}
+
+	if err := parseArgs(t, "--profile", "work"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(flagRepos) != 3 || len(flagOrgs) != 1 || flagDays != 3 {
+		t.Errorf("Expected the profile's settings to apply, got repos %q, orgs %q, days %d", flagRepos, flagOrgs, flagDays)
+	}
+}
+
+func TestConfigSetRejectsSeveralValuesForScalar(t *testing.T) {
+	setupConfig(t, "")
+
+	if err := runConfigSet(configSetCmd, []string{"days", "1", "2"}); err == nil {
+		t.Error("Expected error for several values of days")
+	}
+	if err := runConfigSet(configSetCmd, []string{"days", "soon"}); err == nil {
+		t.Error("Expected error for an invalid number of days")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gh-standup/internal/eval"
+	"github.com/gh-standup/internal/llm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagEvalModels []string
+	flagJudgeModel string
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate the prompt against its test data",
+	Long: `Generate a report for every test case in the prompt's testData with each
+model, check the reports with the prompt's evaluators and print a pass/fail
+matrix. Exits with an error when any check fails.`,
+	Args: cobra.NoArgs,
+	// Failed checks are not usage errors
+	SilenceUsage: true,
+	RunE:         runEval,
+}
+
+func init() {
+	evalCmd.Flags().StringSliceVar(&flagEvalModels, "models", nil, "Models to evaluate (defaults to the model the prompt is normally run with)")
+	evalCmd.Flags().StringVar(&flagJudgeModel, "judge-model", "", "Model that grades reports for llm evaluators without a modelId (defaults to the evaluated model)")
+	rootCmd.AddCommand(evalCmd)
+}
+
+func runEval(cmd *cobra.Command, args []string) error {
+	promptConfig, err := llm.LoadPromptConfig(flagPromptFile)
+	if err != nil {
+		return err
+	}
+
+	provider, err := llm.NewProvider(llm.ProviderOptions{
+		Name:    flagProvider,
+		BaseURL: flagBaseURL,
+		Retry:   retryOptions(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create LLM client: %w", err)
+	}
+
+	runner := &eval.Runner{Provider: provider, JudgeModel: flagJudgeModel}
+	results, err := runner.Run(cmd.Context(), promptConfig, flagEvalModels)
+	if err != nil {
+		return err
+	}
+
+	if err := eval.WriteMatrix(os.Stdout, promptConfig.Evaluators, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d evaluation runs failed", failed, len(results))
+	}
+	return nil
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
+	// Time zone names for --timezone on systems without a zoneinfo database
+	_ "time/tzdata"
 
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/gh-standup/internal/activityio"
 	"github.com/gh-standup/internal/github"
+	"github.com/gh-standup/internal/history"
 	"github.com/gh-standup/internal/llm"
+	"github.com/gh-standup/internal/retry"
+	"github.com/gh-standup/internal/timeframe"
 	"github.com/gh-standup/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -24,47 +33,166 @@ var rootCmd = &cobra.Command{
 
 var (
 	flagDays    int
+	flagSince   string
+	flagUntil   string
 	flagModel   string
 	flagPrompts []string
-	flagRepo    string
+	flagRepos   []string
+	flagOrgs    []string
+	flagExclude []string
 	flagUser    string
+	flagSources []string
+	flagBackend string
+
+	flagSinceLastWorkday bool
+	flagWorkdays         []string
+	flagHolidays         string
+	flagStandupTime      string
+	flagTimezone         string
+
+	flagConcurrency   int
+	flagSourceTimeout time.Duration
+
+	flagProvider   string
+	flagBaseURL    string
+	flagPromptFile string
+
+	flagFormat    string
+	flagRaw       bool
+	flagFromFiles []string
+	flagNoStream  bool
+	flagStats     bool
+
+	flagCompareModels []string
+
+	flagTemperature      float64
+	flagTopP             float64
+	flagMaxTokens        int
+	flagSeed             int
+	flagStop             []string
+	flagPresencePenalty  float64
+	flagFrequencyPenalty float64
+	flagResponseFormat   string
+
+	flagMaxAttempts  int
+	flagMaxRetryWait time.Duration
+)
+
+// Output formats besides the activityio export formats.
+const (
+	// formatReport generates the standup report with the model
+	formatReport = "report"
+	// formatText prints the activity summary that would be sent to the model
+	formatText = "text"
 )
 
 func init() {
-	rootCmd.Flags().IntVarP(&flagDays, "days", "d", 1, "Number of days to look back for activity")
-	rootCmd.Flags().StringVarP(&flagModel, "model", "m", "openai/gpt-4o", "GitHub Models model to use")
+	rootCmd.Flags().IntVarP(&flagDays, "days", "d", 1, "Number of days to look back for activity, instead of since the last workday")
+	rootCmd.Flags().StringVar(&flagSince, "since", "", "Start of the period to report on: a date, an RFC 3339 timestamp or an expression such as \"yesterday\", \"last friday 17:00\" or \"2 weeks ago\"")
+	rootCmd.Flags().StringVar(&flagUntil, "until", "", "End of the period to report on, in the same formats as --since; dates include the whole day (defaults to now)")
+	rootCmd.MarkFlagsMutuallyExclusive("days", "since")
+	rootCmd.Flags().BoolVar(&flagSinceLastWorkday, "since-last-workday", true, "Unless --days or --since is given, report since the previous workday's standup, skipping weekends and holidays")
+	rootCmd.Flags().StringSliceVar(&flagWorkdays, "workdays", timeframe.DefaultWorkdays, "Days of the work week")
+	rootCmd.Flags().StringVar(&flagHolidays, "holidays", "", "Days off that are not workdays: an ICS calendar or a YAML list of dates")
+	rootCmd.Flags().StringVar(&flagTimezone, "timezone", "", "Time zone of dates, times and workdays, e.g. Europe/Berlin (defaults to TZ or the system time zone)")
+	rootCmd.Flags().StringVar(&flagStandupTime, "standup-time", "", "Time of the daily standup, e.g. 09:30 (defaults to the time of day the report is generated)")
+	rootCmd.Flags().StringVarP(&flagModel, "model", "m", "", "Model to use (defaults to the prompt's model on GitHub Models, or the provider's default model)")
+	rootCmd.PersistentFlags().StringVar(&flagProvider, "provider", llm.ProviderGitHub, fmt.Sprintf("Model provider (available: %s)", strings.Join(llm.ProviderNames, ", ")))
+	rootCmd.PersistentFlags().StringVar(&flagBaseURL, "base-url", "", "Base URL of the model provider's API (API keys are read from OPENAI_API_KEY or ANTHROPIC_API_KEY)")
 	rootCmd.Flags().StringArrayVarP(&flagPrompts, "prompts", "p", nil, "Override default prompt messages (can be specified multiple times) in format role:message")
-	rootCmd.Flags().StringVarP(&flagRepo, "repo", "r", "", "Repository to generate standup for (owner/repo)")
+	rootCmd.PersistentFlags().StringVar(&flagPromptFile, "prompt-file", "", fmt.Sprintf("Prompt configuration to use (defaults to .github/%s in the current repository or %s in the gh config directory)", llm.PromptFileName, llm.PromptFileName))
+	rootCmd.Flags().StringSliceVarP(&flagRepos, "repo", "r", nil, "Repositories to generate standup for, as owner/repo or a pattern such as acme/*-service (can be specified multiple times)")
+	rootCmd.Flags().StringSliceVar(&flagOrgs, "org", nil, "Organizations or users whose repositories to generate standup for (can be specified multiple times)")
+	rootCmd.Flags().StringSliceVar(&flagExclude, "exclude-repo", nil, "Repositories to leave out, as owner/repo or a pattern (can be specified multiple times)")
 	rootCmd.Flags().StringVarP(&flagUser, "user", "u", "", "User to generate standup for (defaults to authenticated user)")
+	rootCmd.Flags().StringVar(&flagBackend, "backend", github.BackendSearch, fmt.Sprintf("Backend whose default activity sources are used (available: %s)", strings.Join(github.Backends(), ", ")))
+	rootCmd.Flags().StringSliceVar(&flagSources, "sources", nil, fmt.Sprintf("Activity sources to collect from, overriding the backend defaults (available: %s)", strings.Join(github.SourceNames(), ", ")))
+	rootCmd.Flags().StringVarP(&flagFormat, "format", "f", formatReport, fmt.Sprintf("Output format: %s, %s or one of the raw activity formats (%s)", formatReport, formatText, strings.Join(activityio.Formats, ", ")))
+	rootCmd.Flags().BoolVar(&flagRaw, "raw", false, "Print the collected activity as JSON instead of generating a report (same as --format json)")
+	rootCmd.Flags().StringArrayVar(&flagFromFiles, "from-file", nil, "Load previously exported activity (JSON or JSON Lines) instead of querying GitHub; use - for stdin (can be specified multiple times)")
+	rootCmd.Flags().StringSliceVar(&flagCompareModels, "compare-models", nil, "Generate the report with each of these models concurrently and print them one after another with latency and token usage")
+	rootCmd.Flags().Float64Var(&flagTemperature, "temperature", 0, "Sampling temperature, overriding the prompt's modelParameters")
+	rootCmd.Flags().Float64Var(&flagTopP, "top-p", 0, "Nucleus sampling probability, overriding the prompt's modelParameters")
+	rootCmd.Flags().IntVar(&flagMaxTokens, "max-tokens", 0, "Maximum number of tokens in the report")
+	rootCmd.Flags().IntVar(&flagSeed, "seed", 0, "Seed for more reproducible reports, on models that support it")
+	rootCmd.Flags().StringArrayVar(&flagStop, "stop", nil, "Sequence at which the model stops writing (can be specified multiple times)")
+	rootCmd.Flags().Float64Var(&flagPresencePenalty, "presence-penalty", 0, "Presence penalty between -2 and 2")
+	rootCmd.Flags().Float64Var(&flagFrequencyPenalty, "frequency-penalty", 0, "Frequency penalty between -2 and 2")
+	rootCmd.Flags().StringVar(&flagResponseFormat, "response-format", "", fmt.Sprintf("Response format (available: %s)", strings.Join(llm.ResponseFormats, ", ")))
+	rootCmd.Flags().BoolVar(&flagStats, "stats", false, "Print the model, latency and token usage of the report to stderr")
+	rootCmd.Flags().BoolVar(&flagNoStream, "no-stream", false, "Wait for the complete report instead of printing it as it is generated")
+	rootCmd.PersistentFlags().IntVar(&flagMaxAttempts, "max-attempts", retry.DefaultMaxAttempts, "Maximum number of attempts for each GitHub or model API request (1 disables retries)")
+	rootCmd.PersistentFlags().DurationVar(&flagMaxRetryWait, "max-retry-wait", retry.DefaultMaxWait, "Longest wait before retrying a rate-limited request; longer rate limits fail instead")
+	rootCmd.Flags().IntVar(&flagConcurrency, "concurrency", 4, "Maximum number of activity sources collected at once")
+	rootCmd.Flags().DurationVar(&flagSourceTimeout, "source-timeout", 60*time.Second, "Maximum time spent on a single activity source before it is skipped (0 disables)")
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		stop()
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 func runStandup(cmd *cobra.Command, args []string) error {
-	githubClient, err := github.NewClient()
+	ctx := cmd.Context()
+
+	format, err := outputFormat(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to create GitHub client: %w", err)
+		return err
+	}
+	if format, err = resolveCompareModels(cmd, format); err != nil {
+		return err
+	}
+	params, err := modelParameters(cmd)
+	if err != nil {
+		return err
 	}
 
-	if flagUser == "" {
-		user, err := githubClient.GetCurrentUser()
-		if err != nil {
-			return fmt.Errorf("failed to get current user: %w", err)
-		}
-		flagUser = user
+	now, err := currentTime()
+	if err != nil {
+		return err
 	}
 
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -flagDays)
+	repos, err := github.NewRepoFilter(flagRepos, flagOrgs, flagExclude)
+	if err != nil {
+		return err
+	}
 
-	activities, err := githubClient.CollectActivity(flagUser, flagRepo, startDate, endDate)
+	var startDate, endDate time.Time
+	var activities []types.GitHubActivity
+	if len(flagFromFiles) > 0 {
+		if activities, err = loadActivities(flagFromFiles, repos); err != nil {
+			return err
+		}
+		startDate, endDate, err = replayPeriod(cmd, activities, now)
+	} else {
+		if startDate, endDate, err = reportPeriod(cmd, now); err != nil {
+			return err
+		}
+		activities, err = collectActivities(ctx, startDate, endDate, repos)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to collect GitHub activity: %w", err)
+		return err
+	}
+
+	log.Printf("Found %d activities\n", len(activities))
+	if len(activities) > 0 {
+		log.Printf("   %s\n", summarizeCounts(types.CountByKind(activities)))
+	}
+
+	switch format {
+	case formatReport:
+		// Generated below
+	case formatText:
+		fmt.Print(llm.FormatActivities(activities))
+		return nil
+	default:
+		return activityio.Write(os.Stdout, format, activities)
 	}
 
 	if len(activities) == 0 {
@@ -72,15 +200,28 @@ func runStandup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	log.Printf("Found %d activities\n", len(activities))
-
-	commits, prs, issues, reviews := countActivities(activities)
-	log.Printf("   %d commits, %d pull requests, %d issues, %d reviews\n", commits, prs, issues, reviews)
-
-	llmClient, err := llm.NewClient()
+	provider, err := llm.NewProvider(llm.ProviderOptions{
+		Name:    flagProvider,
+		BaseURL: flagBaseURL,
+		Retry:   retryOptions(),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create LLM client: %w", err)
 	}
+	llmClient := llm.NewClient(provider)
+
+	promptConfig, err := llm.LoadPromptConfig(flagPromptFile)
+	if err != nil {
+		return err
+	}
+	llmClient.SetPromptConfig(promptConfig)
+	llmClient.SetModelParameters(params)
+
+	// Show the report as it is generated when someone is watching
+	streaming := !flagNoStream && term.FromEnv().IsTerminalOutput()
+	if streaming {
+		llmClient.SetStreamWriter(os.Stdout)
+	}
 
 	var promptMessages []llm.PromptMessage
 	if len(flagPrompts) > 0 {
@@ -96,29 +237,341 @@ func runStandup(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Generate standup report using GitHub Models
-	report, err := llmClient.GenerateStandupReport(activities, flagModel, promptMessages)
+	info := llm.ReportInfo{
+		User:      flagUser,
+		Repo:      strings.Join(append(append([]string{}, flagRepos...), flagOrgs...), ", "),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+	standups := loadPreviousStandup(&info)
+
+	if len(flagCompareModels) > 0 {
+		return compareModels(ctx, llmClient, activities, info, promptMessages)
+	}
+
+	// Generate standup report using the selected model provider
+	report, err := llmClient.GenerateStandupReport(ctx, activities, info, flagModel, promptMessages)
 	if err != nil {
 		return fmt.Errorf("failed to generate standup report: %w", err)
 	}
 
-	fmt.Println(report)
+	// Only a report on live activity up to now is the next report's previous
+	// standup; replays and back-dated periods are not saved
+	if standups != nil && len(flagFromFiles) == 0 && endDate.Equal(now) {
+		entry := history.Entry{Report: report.Text, StartDate: startDate, EndDate: endDate, CreatedAt: now}
+		if err := standups.Save(flagUser, entry); err != nil {
+			log.Printf("  ⚠️  %v\n", err)
+		}
+	}
+
+	// A streamed report has already been printed
+	if !streaming {
+		fmt.Println(report.Text)
+	}
+
+	if flagStats {
+		fmt.Fprintln(os.Stderr)
+		if err := llm.WriteStats(os.Stderr, report); err != nil {
+			return fmt.Errorf("failed to write stats: %w", err)
+		}
+	}
 
 	return nil
 }
 
-func countActivities(activities []types.GitHubActivity) (commits, prs, issues, reviews int) {
-	for _, activity := range activities {
-		switch activity.Type {
-		case "commit":
-			commits++
-		case "pull_request":
-			prs++
-		case "issue":
-			issues++
-		case "review":
-			reviews++
+// compareModels prints the report of each --compare-models model. It fails
+// only when no model produced a report.
+func compareModels(ctx context.Context, llmClient *llm.Client, activities []types.GitHubActivity, info llm.ReportInfo, promptMessages []llm.PromptMessage) error {
+	comparisons := llmClient.CompareModels(ctx, activities, info, flagCompareModels, promptMessages)
+	if err := llm.WriteComparison(os.Stdout, comparisons); err != nil {
+		return err
+	}
+
+	for _, comparison := range comparisons {
+		if comparison.Err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to generate standup report with any of the models")
+}
+
+// resolveCompareModels settles a conflict between --compare-models and
+// --model or an output format in favor of the one given with the higher
+// precedence, and returns the output format to use.
+func resolveCompareModels(cmd *cobra.Command, format string) (string, error) {
+	if len(flagCompareModels) > 0 && flagModel != "" {
+		preferred, err := preferFlag(cmd, "compare-models", "model")
+		if err != nil {
+			return "", err
+		}
+		if preferred == "model" {
+			flagCompareModels = nil
+		}
+	}
+
+	if len(flagCompareModels) > 0 && format != formatReport {
+		formatFlag := "format"
+		if flagRaw {
+			formatFlag = "raw"
+		}
+		preferred, err := preferFlag(cmd, "compare-models", formatFlag)
+		if err != nil {
+			return "", err
+		}
+		if preferred == formatFlag {
+			flagCompareModels = nil
+		} else {
+			format = formatReport
+		}
+	}
+	return format, nil
+}
+
+// currentTime returns the time in the zone selected by --timezone, which
+// expressions such as "yesterday" and workdays are resolved in.
+func currentTime() (time.Time, error) {
+	if flagTimezone == "" {
+		return time.Now(), nil
+	}
+	location, err := time.LoadLocation(flagTimezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --timezone: %w", err)
+	}
+	return time.Now().In(location), nil
+}
+
+// reportPeriod returns the period selected by the flags. It ends at --until
+// and starts at --since, --days before its end or, by default, at the
+// previous workday's standup.
+func reportPeriod(cmd *cobra.Command, now time.Time) (time.Time, time.Time, error) {
+	endDate := now
+	if flagUntil != "" {
+		var err error
+		if endDate, err = timeframe.ParseUntil(flagUntil, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+
+	// --since and --days on the command line are rejected by cobra; from
+	// different sources, the one with the higher precedence wins
+	useSince := flagSince != ""
+	if useSince && isSet(cmd, "days") {
+		preferred, err := preferFlag(cmd, "since", "days")
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		useSince = preferred == "since"
+	}
+
+	startDate := endDate.AddDate(0, 0, -flagDays)
+	switch {
+	case useSince:
+		var err error
+		if startDate, err = timeframe.ParseSince(flagSince, now); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --since: %w", err)
+		}
+	case flagSinceLastWorkday && !isSet(cmd, "days"):
+		calendar, err := timeframe.NewCalendar(flagWorkdays, flagHolidays, flagStandupTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if startDate, err = calendar.SinceLastWorkday(endDate); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		log.Printf("Reporting on activity since the last workday, %s\n", startDate.Format("Monday Jan 2 15:04"))
+	}
+
+	if !startDate.Before(endDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the period starts at %s, which is not before its end at %s", startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	}
+	return startDate, endDate, nil
+}
+
+// replayPeriod returns the period of loaded activity: the one selected by
+// --since, --until or --days, or else the span of the activity itself, so
+// that replaying the same file yields the same report on any day.
+func replayPeriod(cmd *cobra.Command, activities []types.GitHubActivity, now time.Time) (time.Time, time.Time, error) {
+	if isSet(cmd, "since") || isSet(cmd, "until") || isSet(cmd, "days") {
+		return reportPeriod(cmd, now)
+	}
+	startDate, endDate := types.Span(activities)
+	return startDate.In(now.Location()), endDate.In(now.Location()), nil
+}
+
+// modelParameters returns the model parameters set on the command line, which
+// override the prompt's.
+func modelParameters(cmd *cobra.Command) (llm.ModelParameters, error) {
+	var params llm.ModelParameters
+	if isSet(cmd, "temperature") {
+		params.Temperature = &flagTemperature
+	}
+	if isSet(cmd, "top-p") {
+		params.TopP = &flagTopP
+	}
+	if isSet(cmd, "seed") {
+		params.Seed = &flagSeed
+	}
+	if isSet(cmd, "presence-penalty") {
+		params.PresencePenalty = &flagPresencePenalty
+	}
+	if isSet(cmd, "frequency-penalty") {
+		params.FrequencyPenalty = &flagFrequencyPenalty
+	}
+	params.MaxTokens = flagMaxTokens
+	params.Stop = flagStop
+	params.ResponseFormat = flagResponseFormat
+
+	if err := params.Validate(); err != nil {
+		return params, fmt.Errorf("invalid model parameter: %w", err)
+	}
+	return params, nil
+}
+
+// retryOptions returns the retry configuration selected by the flags.
+func retryOptions() retry.Options {
+	return retry.Options{
+		MaxAttempts: flagMaxAttempts,
+		MaxWait:     flagMaxRetryWait,
+	}
+}
+
+// loadPreviousStandup fills in the newest report generated for the user up to
+// the start of the period, so that prompts can refer to it. It returns the
+// store to save the new report to, or nil when the user is unknown or the
+// history is unavailable.
+func loadPreviousStandup(info *llm.ReportInfo) *history.Store {
+	if info.User == "" {
+		return nil
+	}
+
+	store, err := history.NewStore()
+	if err != nil {
+		log.Printf("  ⚠️  %v\n", err)
+		return nil
+	}
+	previous, err := store.Previous(info.User, info.StartDate)
+	if err != nil {
+		log.Printf("  ⚠️  %v\n", err)
+		return store
+	}
+	if previous != nil {
+		info.PreviousStandup = previous.Report
+		info.PreviousStandupDate = previous.CreatedAt
+	}
+	return store
+}
+
+// collectActivities queries GitHub for the activity selected by the flags
+// between startDate and endDate in the repositories selected by repos.
+func collectActivities(ctx context.Context, startDate, endDate time.Time, repos github.RepoFilter) ([]types.GitHubActivity, error) {
+	sourceNames := flagSources
+	if len(sourceNames) == 0 {
+		sourceNames = github.DefaultSourceNames(flagBackend)
+		if len(sourceNames) == 0 {
+			return nil, fmt.Errorf("unknown backend %q (available: %s)", flagBackend, strings.Join(github.Backends(), ", "))
+		}
+	}
+
+	githubClient, err := github.NewClient(retryOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	if flagUser == "" {
+		user, err := githubClient.GetCurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		flagUser = user
+	}
+
+	sources, err := githubClient.Sources(sourceNames)
+	if err != nil {
+		return nil, err
+	}
+
+	query := github.Query{
+		Username:  flagUser,
+		Repos:     repos,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	activities, err := githubClient.CollectActivity(ctx, query, sources, github.CollectOptions{
+		Concurrency:   flagConcurrency,
+		SourceTimeout: flagSourceTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect GitHub activity: %w", err)
+	}
+
+	return activities, nil
+}
+
+// loadActivities reads and combines previously exported activity files,
+// keeping the activity in the repositories selected by repos.
+func loadActivities(paths []string, repos github.RepoFilter) ([]types.GitHubActivity, error) {
+	var activities []types.GitHubActivity
+	for _, path := range paths {
+		log.Printf("  Loading activity from %s... ", path)
+		loaded, err := activityio.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load activity from %s: %w", path, err)
+		}
+		log.Printf("Found %d\n", len(loaded))
+		for _, activity := range loaded {
+			if repos.Match(activity.Repository) {
+				activities = append(activities, activity)
+			}
+		}
+	}
+	return activities, nil
+}
+
+// outputFormat validates --format and folds --raw into it.
+func outputFormat(cmd *cobra.Command) (string, error) {
+	useRaw := flagRaw
+	if flagRaw && isSet(cmd, "format") && flagFormat != activityio.FormatJSON {
+		preferred, err := preferFlag(cmd, "raw", "format")
+		if err != nil {
+			return "", err
+		}
+		useRaw = preferred == "raw"
+	}
+	if useRaw {
+		return activityio.FormatJSON, nil
+	}
+
+	switch flagFormat {
+	case formatReport, formatText:
+		return flagFormat, nil
+	}
+	for _, format := range activityio.Formats {
+		if flagFormat == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (available: %s, %s, %s)", flagFormat, formatReport, formatText, strings.Join(activityio.Formats, ", "))
+}
+
+// kindLabels names each activity kind in the collection summary.
+var kindLabels = map[types.ActivityKind]string{
+	types.KindCommit:           "commits",
+	types.KindPullRequest:      "pull requests",
+	types.KindPullRequestEvent: "pull request updates",
+	types.KindIssue:            "issues",
+	types.KindReview:           "reviews",
+	types.KindComment:          "comments",
+	types.KindCreate:           "branches and tags",
+	types.KindRelease:          "releases",
+}
+
+func summarizeCounts(counts map[types.ActivityKind]int) string {
+	var parts []string
+	for _, kind := range types.Kinds {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kindLabels[kind]))
 		}
 	}
-	return
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+func TestReplayPeriodFromActivity(t *testing.T) {
+	setupConfig(t, "")
+	if err := parseArgs(t, "--from-file", "activities.json"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	first := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 5, 3, 17, 0, 0, 0, time.UTC)
+	activities := []types.GitHubActivity{{CreatedAt: last}, {CreatedAt: first}}
+
+	for _, now := range []time.Time{time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC), time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)} {
+		startDate, endDate, err := replayPeriod(rootCmd, activities, now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !startDate.Equal(first) || !endDate.Equal(last) {
+			t.Errorf("Expected the span of the activity on %v, got %v to %v", now, startDate, endDate)
+		}
+	}
+}
+
+func TestReplayPeriodFromFlags(t *testing.T) {
+	setupConfig(t, "")
+	if err := parseArgs(t, "--from-file", "activities.json", "--since", "2024-05-01", "--until", "2024-05-01"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	startDate, _, err := replayPeriod(rootCmd, []types.GitHubActivity{{CreatedAt: now}}, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local); !startDate.Equal(want) {
+		t.Errorf("Expected the period from the flags, got %v", startDate)
+	}
+}
+
+func TestModelParametersZeroOverrides(t *testing.T) {
+	setupConfig(t, "")
+	if err := parseArgs(t, "--temperature", "0", "--presence-penalty", "0", "--frequency-penalty", "0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	params, err := modelParameters(rootCmd)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for name, value := range map[string]*float64{"temperature": params.Temperature, "presence-penalty": params.PresencePenalty, "frequency-penalty": params.FrequencyPenalty} {
+		if value == nil || *value != 0 {
+			t.Errorf("Expected an explicit zero %s to be kept, got %v", name, value)
+		}
+	}
+	if params.TopP != nil {
+		t.Errorf("Expected an unset top-p to be left to the prompt, got %v", *params.TopP)
+	}
+}
+
+func TestModelParametersOutOfRange(t *testing.T) {
+	for _, args := range [][]string{
+		{"--temperature", "3"},
+		{"--top-p", "1.5"},
+		{"--presence-penalty", "-2.5"},
+		{"--frequency-penalty", "2.5"},
+		{"--max-tokens", "-1"},
+		{"--response-format", "yaml"},
+	} {
+		setupConfig(t, "")
+		if err := parseArgs(t, args...); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := modelParameters(rootCmd); err == nil {
+			t.Errorf("Expected error for %q", args)
+		}
+		resetFlags()
+	}
+}
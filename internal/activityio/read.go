@@ -0,0 +1,70 @@
+package activityio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// Read decodes activities written by Write in the JSON or JSON Lines format.
+func Read(r io.Reader) ([]types.GitHubActivity, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var activities []types.GitHubActivity
+		if err := json.Unmarshal(trimmed, &activities); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON activities: %w", err)
+		}
+		return activities, nil
+	}
+
+	var activities []types.GitHubActivity
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var activity types.GitHubActivity
+		if err := json.Unmarshal(text, &activity); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON Lines activity on line %d: %w", line, err)
+		}
+		activities = append(activities, activity)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// ReadFile reads activities from path, or from stdin when path is "-".
+func ReadFile(path string) ([]types.GitHubActivity, error) {
+	if path == "-" {
+		return Read(os.Stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
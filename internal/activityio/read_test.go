@@ -0,0 +1,44 @@
+package activityio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatJSONL} {
+		var buf bytes.Buffer
+		if err := Write(&buf, format, testActivities); err != nil {
+			t.Fatalf("Unexpected error writing %s: %v", format, err)
+		}
+
+		activities, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %v", format, err)
+		}
+		if len(activities) != len(testActivities) {
+			t.Fatalf("Expected %d activities from %s, got %d", len(testActivities), format, len(activities))
+		}
+		if activities[0].Title != testActivities[0].Title || !activities[0].CreatedAt.Equal(testActivities[0].CreatedAt) {
+			t.Errorf("Activity did not round-trip through %s: %+v", format, activities[0])
+		}
+	}
+}
+
+func TestReadEmpty(t *testing.T) {
+	activities, err := Read(strings.NewReader("  \n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 0 {
+		t.Errorf("Expected no activities, got %d", len(activities))
+	}
+}
+
+func TestReadReportsBadLine(t *testing.T) {
+	_, err := Read(strings.NewReader("{\"type\":\"commit\"}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error mentioning line 2, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package activityio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// Export formats understood by Write.
+const (
+	FormatJSON  = "json"
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Formats lists the export formats understood by Write.
+var Formats = []string{FormatJSON, FormatJSONL, FormatCSV}
+
+// csvHeader lists the columns written in CSV exports. Per-kind details are
+// only available in the JSON formats.
+var csvHeader = []string{"type", "repository", "number", "title", "state", "author", "url", "created_at", "description"}
+
+// Write encodes activities to w in the given format.
+func Write(w io.Writer, format string, activities []types.GitHubActivity) error {
+	switch format {
+	case FormatJSON:
+		if activities == nil {
+			activities = []types.GitHubActivity{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(activities)
+
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		for _, activity := range activities {
+			if err := encoder.Encode(activity); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, activity := range activities {
+			number := ""
+			if activity.Number != 0 {
+				number = strconv.Itoa(activity.Number)
+			}
+			record := []string{
+				string(activity.Type),
+				activity.Repository,
+				number,
+				activity.Title,
+				activity.State,
+				activity.Author,
+				activity.URL,
+				activity.CreatedAt.Format(time.RFC3339),
+				activity.Description,
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
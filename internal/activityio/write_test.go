@@ -0,0 +1,98 @@
+package activityio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+var testActivities = []types.GitHubActivity{
+	{
+		Type:        types.KindPullRequest,
+		Repository:  "test/repo",
+		Title:       "PR #1: Add feature",
+		Description: "Adds a feature,\nwith a comma",
+		URL:         "https://github.com/test/repo/pull/1",
+		CreatedAt:   time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		Number:      1,
+		State:       "open",
+	},
+	{
+		Type:       types.KindReview,
+		Repository: "test/repo",
+		Title:      "Reviewed PR #2: Fix bug",
+		Review:     &types.ReviewDetail{State: "APPROVED"},
+	},
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testActivities); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded []types.GitHubActivity
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not a JSON array: %v", err)
+	}
+	if len(decoded) != 2 || decoded[1].Review == nil {
+		t.Errorf("Expected activities to round-trip, got %+v", decoded)
+	}
+}
+
+func TestWriteJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty array, got %q", buf.String())
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSONL, testActivities); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var activity types.GitHubActivity
+		if err := json.Unmarshal([]byte(line), &activity); err != nil {
+			t.Errorf("Line is not a JSON object: %v", err)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testActivities); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 records, got %d", len(records))
+	}
+	if records[1][2] != "1" || records[1][8] != "Adds a feature,\nwith a comma" {
+		t.Errorf("Unexpected record: %v", records[1])
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xml", testActivities); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
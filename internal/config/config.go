@@ -0,0 +1,179 @@
+// Package config reads and writes gh-standup configuration files, which hold
+// default values for command-line flags, optionally grouped in named profiles.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ghconfig "github.com/cli/go-gh/v2/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the configuration file, in the gh config directory
+// for the user and in the .github directory of a repository.
+const FileName = "standup.yml"
+
+// envPrefix starts the environment variables that set flags, as in GH_STANDUP_MODEL.
+const envPrefix = "GH_STANDUP_"
+
+// Settings map flag names to values: a scalar, or a list for flags that can
+// be repeated.
+type Settings map[string]interface{}
+
+// File is a configuration file. Its top-level settings apply to every run,
+// and a selected profile's settings override them.
+type File struct {
+	// Profile names the profile used when none is selected.
+	Profile  string              `yaml:"profile,omitempty"`
+	Profiles map[string]Settings `yaml:"profiles,omitempty"`
+	Settings Settings            `yaml:",inline"`
+}
+
+// UserPath returns the path of the user's configuration file in the gh config
+// directory, e.g. ~/.config/gh/standup.yml, which GH_CONFIG_DIR relocates.
+func UserPath() string {
+	return filepath.Join(ghconfig.ConfigDir(), FileName)
+}
+
+// Load reads the configuration file at path. A missing file is empty.
+func Load(path string) (*File, error) {
+	file := &File{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Save writes the configuration file to path, creating its directory.
+func (f *File) Save(path string) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// Merge combines files, with settings from later files overriding earlier ones.
+func Merge(files ...*File) *File {
+	merged := &File{Settings: Settings{}, Profiles: map[string]Settings{}}
+	for _, file := range files {
+		if file.Profile != "" {
+			merged.Profile = file.Profile
+		}
+		for key, value := range file.Settings {
+			merged.Settings[key] = value
+		}
+		for name, settings := range file.Profiles {
+			if merged.Profiles[name] == nil {
+				merged.Profiles[name] = Settings{}
+			}
+			for key, value := range settings {
+				merged.Profiles[name][key] = value
+			}
+		}
+	}
+	return merged
+}
+
+// Restrict removes the settings not in allowed, at the top level and in
+// every profile, and returns their names in sorted order.
+func (f *File) Restrict(allowed map[string]bool) []string {
+	removed := map[string]bool{}
+	restrict := func(settings Settings) {
+		for name := range settings {
+			if !allowed[name] {
+				delete(settings, name)
+				removed[name] = true
+			}
+		}
+	}
+	restrict(f.Settings)
+	for _, settings := range f.Profiles {
+		restrict(settings)
+	}
+
+	names := make([]string, 0, len(removed))
+	for name := range removed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the settings in effect with profile, or with the file's
+// default profile when profile is empty.
+func (f *File) Resolve(profile string) (Settings, error) {
+	if profile == "" {
+		profile = f.Profile
+	}
+
+	resolved := Settings{}
+	for key, value := range f.Settings {
+		resolved[key] = value
+	}
+	if profile == "" {
+		return resolved, nil
+	}
+
+	settings, ok := f.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(f.ProfileNames(), ", "))
+	}
+	for key, value := range settings {
+		resolved[key] = value
+	}
+	return resolved, nil
+}
+
+// ProfileNames returns the names of the profiles in sorted order.
+func (f *File) ProfileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Values returns a setting as the values to set its flag to, one per
+// repetition of the flag.
+func Values(value interface{}) []string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return []string{fmt.Sprint(value)}
+	}
+	values := make([]string, len(list))
+	for i, item := range list {
+		values[i] = fmt.Sprint(item)
+	}
+	return values
+}
+
+// EnvName returns the environment variable that sets a flag, e.g.
+// GH_STANDUP_BASE_URL for --base-url.
+func EnvName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const userConfig = `model: openai/gpt-4o
+days: 1
+profile: oss
+profiles:
+  work:
+    provider: openai
+    base-url: https://acme.openai.azure.com/openai/v1
+    repo: [acme/api, acme/web]
+  oss:
+    user: octocat
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAndResolve(t *testing.T) {
+	file, err := Load(writeConfig(t, userConfig))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings, err := file.Resolve("work")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings["model"] != "openai/gpt-4o" || settings["provider"] != "openai" {
+		t.Errorf("Expected top-level and profile settings, got %v", settings)
+	}
+	if got := Values(settings["repo"]); !reflect.DeepEqual(got, []string{"acme/api", "acme/web"}) {
+		t.Errorf("Expected a value per repetition, got %q", got)
+	}
+	if got := Values(settings["days"]); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("Expected scalars as a single value, got %q", got)
+	}
+
+	settings, err = file.Resolve("")
+	if err != nil || settings["user"] != "octocat" {
+		t.Errorf("Expected the default profile, got %v, %v", settings, err)
+	}
+
+	if _, err := file.Resolve("play"); err == nil {
+		t.Error("Expected error for an unknown profile")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	file, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings, _ := file.Resolve(""); len(settings) != 0 {
+		t.Errorf("Expected no settings, got %v", settings)
+	}
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	if _, err := Load(writeConfig(t, "profiles: [work]\n")); err == nil {
+		t.Error("Expected error for an invalid file")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	user, _ := Load(writeConfig(t, userConfig))
+	repo, _ := Load(writeConfig(t, "model: openai/gpt-5\nprofiles:\n  work:\n    repo: acme/api\n"))
+
+	settings, err := Merge(user, repo).Resolve("work")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings["model"] != "openai/gpt-5" || settings["repo"] != "acme/api" || settings["provider"] != "openai" {
+		t.Errorf("Expected the repository's settings to override the user's, got %v", settings)
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	file, err := Load(writeConfig(t, userConfig))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	removed := file.Restrict(map[string]bool{"model": true, "repo": true, "user": true})
+	if !reflect.DeepEqual(removed, []string{"base-url", "days", "provider"}) {
+		t.Errorf("Expected the settings that are not allowed to be removed, got %q", removed)
+	}
+
+	settings, err := file.Resolve("work")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := settings["base-url"]; ok || settings["model"] != "openai/gpt-4o" || settings["repo"] == nil {
+		t.Errorf("Expected only the allowed settings, got %v", settings)
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gh-standup", FileName)
+	file := &File{Profile: "work", Settings: Settings{"days": "3"}, Profiles: map[string]Settings{"work": {"org": "acme"}}}
+	if err := file.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, file) {
+		t.Errorf("Expected %+v, got %+v", file, loaded)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got := EnvName("base-url"); got != "GH_STANDUP_BASE_URL" {
+		t.Errorf("Unexpected name %q", got)
+	}
+}
@@ -0,0 +1,234 @@
+// Package eval runs a prompt's test data through models and checks the
+// generated reports with the prompt's evaluators.
+package eval
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gh-standup/internal/llm"
+)
+
+// UsesNoMarkdownHeaders names the built-in check, used with `uses`, that fails
+// reports containing markdown headers.
+const UsesNoMarkdownHeaders = "no-markdown-headers"
+
+var markdownHeader = regexp.MustCompile(`(?m)^ {0,3}#{1,6}(\s|$)`)
+
+// Outcome is the verdict of one evaluator on one report.
+type Outcome struct {
+	Evaluator string
+	Passed    bool
+	// Reason explains a failure.
+	Reason string
+}
+
+// Result is one test case run through one model.
+type Result struct {
+	// Case is the index of the test case in the prompt's test data.
+	Case   int
+	Model  string
+	Report string
+	// Err is set when the report could not be generated; there are no outcomes then.
+	Err      error
+	Outcomes []Outcome
+}
+
+// Passed reports whether the report was generated and passed every evaluator.
+func (r Result) Passed() bool {
+	if r.Err != nil {
+		return false
+	}
+	for _, outcome := range r.Outcomes {
+		if !outcome.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Runner generates reports and judges them. Model calls go through Provider,
+// so a fake provider makes evaluations run offline.
+type Runner struct {
+	Provider llm.Provider
+	// JudgeModel is used by LLM evaluators that do not name a model. Empty
+	// uses the model that generated the report.
+	JudgeModel string
+}
+
+// Run generates a report for every test case of config with each model and
+// applies config's evaluators to it. An empty models list uses the model the
+// prompt would normally be run with.
+func (r *Runner) Run(ctx context.Context, config *llm.PromptConfig, models []string) ([]Result, error) {
+	if len(config.TestData) == 0 {
+		return nil, fmt.Errorf("the prompt has no testData to evaluate")
+	}
+	for i, evaluator := range config.Evaluators {
+		if err := Validate(evaluator); err != nil {
+			return nil, fmt.Errorf("invalid evaluator %d: %w", i+1, err)
+		}
+	}
+	if len(models) == 0 {
+		models = []string{""}
+	}
+
+	client := llm.NewClient(r.Provider)
+	client.SetPromptConfig(config)
+
+	var results []Result
+	for i, testCase := range config.TestData {
+		for _, model := range models {
+			log.Printf("Evaluating test case %d with %s\n", i+1, modelLabel(model))
+			result := Result{Case: i + 1, Model: model}
+			info := llm.ReportInfo{User: testCase["user"], Repo: testCase["repo"]}
+			report, err := client.GenerateFromSummary(ctx, testCase["activities"], info, model)
+			if err != nil {
+				result.Err = err
+			} else {
+				result.Report = report.Text
+				for _, evaluator := range config.Evaluators {
+					result.Outcomes = append(result.Outcomes, r.evaluate(ctx, evaluator, testCase["activities"], result.Report, report.Model))
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// Validate checks that an evaluator sets exactly one known kind of check.
+func Validate(evaluator llm.Evaluator) error {
+	kinds := 0
+	if evaluator.String != nil {
+		kinds++
+	}
+	if evaluator.Regex != "" {
+		kinds++
+		if _, err := regexp.Compile(evaluator.Regex); err != nil {
+			return fmt.Errorf("%s: invalid regex: %w", evaluator.Name, err)
+		}
+	}
+	if evaluator.MaxLength > 0 {
+		kinds++
+	}
+	if evaluator.Uses != "" {
+		kinds++
+		if evaluator.Uses != UsesNoMarkdownHeaders {
+			return fmt.Errorf("%s: unknown built-in evaluator %q (available: %s)", evaluator.Name, evaluator.Uses, UsesNoMarkdownHeaders)
+		}
+	}
+	if evaluator.LLM != nil {
+		kinds++
+		if evaluator.LLM.Rubric == "" {
+			return fmt.Errorf("%s: an llm evaluator needs a rubric", evaluator.Name)
+		}
+	}
+
+	if kinds != 1 {
+		return fmt.Errorf("%s: expected exactly one of string, regex, maxLength, uses or llm", evaluator.Name)
+	}
+	return nil
+}
+
+// evaluate applies a validated evaluator to a report generated from input by model.
+func (r *Runner) evaluate(ctx context.Context, evaluator llm.Evaluator, input, report, model string) Outcome {
+	outcome := Outcome{Evaluator: evaluator.Name}
+	switch {
+	case evaluator.String != nil:
+		outcome.Reason = checkString(*evaluator.String, report)
+	case evaluator.Regex != "":
+		if !regexp.MustCompile(evaluator.Regex).MatchString(report) {
+			outcome.Reason = fmt.Sprintf("does not match %q", evaluator.Regex)
+		}
+	case evaluator.MaxLength > 0:
+		if length := utf8.RuneCountInString(report); length > evaluator.MaxLength {
+			outcome.Reason = fmt.Sprintf("%d characters, more than %d", length, evaluator.MaxLength)
+		}
+	case evaluator.Uses == UsesNoMarkdownHeaders:
+		if markdownHeader.MatchString(report) {
+			outcome.Reason = "contains a markdown header"
+		}
+	case evaluator.LLM != nil:
+		outcome.Reason = r.judge(ctx, *evaluator.LLM, input, report, model)
+	}
+	outcome.Passed = outcome.Reason == ""
+	return outcome
+}
+
+// checkString returns why report fails the string checks, or an empty string.
+func checkString(check llm.StringEvaluator, report string) string {
+	switch {
+	case check.Contains != "" && !strings.Contains(report, check.Contains):
+		return fmt.Sprintf("does not contain %q", check.Contains)
+	case check.NotContains != "" && strings.Contains(report, check.NotContains):
+		return fmt.Sprintf("contains %q", check.NotContains)
+	case check.StartsWith != "" && !strings.HasPrefix(report, check.StartsWith):
+		return fmt.Sprintf("does not start with %q", check.StartsWith)
+	case check.EndsWith != "" && !strings.HasSuffix(report, check.EndsWith):
+		return fmt.Sprintf("does not end with %q", check.EndsWith)
+	case check.Equals != "" && report != check.Equals:
+		return fmt.Sprintf("is not %q", check.Equals)
+	}
+	return ""
+}
+
+const judgeSystemPrompt = `You grade standup reports written from a developer's GitHub activity.
+Decide whether the report meets the rubric. Answer with PASS or FAIL on the
+first line, followed by a one sentence reason.`
+
+// judge asks a model whether report meets the rubric. It returns why the
+// report fails, or an empty string when it passes.
+func (r *Runner) judge(ctx context.Context, evaluator llm.LLMEvaluator, input, report, model string) string {
+	judgeModel := evaluator.ModelID
+	if judgeModel == "" {
+		judgeModel = r.JudgeModel
+	}
+	if judgeModel == "" {
+		judgeModel = model
+	}
+
+	request := llm.Request{
+		Model: judgeModel,
+		Messages: []llm.Message{
+			{Role: "system", Content: judgeSystemPrompt},
+			{Role: "user", Content: fmt.Sprintf("Rubric:\n%s\n\nGitHub activity:\n%s\n\nReport:\n%s", evaluator.Rubric, input, report)},
+		},
+	}
+	response, err := r.Provider.Complete(ctx, request)
+	if err != nil {
+		return fmt.Sprintf("judge failed: %v", err)
+	}
+	if len(response.Choices) == 0 {
+		return "judge gave no answer"
+	}
+
+	// Judges often decorate the verdict, as in "**FAIL**: reason"
+	answer := strings.TrimSpace(response.Choices[0].Message.Content)
+	words := strings.Fields(answer)
+	if len(words) == 0 {
+		return "judge gave no answer"
+	}
+	switch strings.ToUpper(strings.Trim(words[0], "*.:,-")) {
+	case "PASS":
+		return ""
+	case "FAIL":
+		if reason := strings.Join(words[1:], " "); reason != "" {
+			return reason
+		}
+		return "judged as failing"
+	default:
+		return fmt.Sprintf("judge gave an unclear answer: %q", answer)
+	}
+}
+
+func modelLabel(model string) string {
+	if model == "" {
+		return "the default model"
+	}
+	return model
+}
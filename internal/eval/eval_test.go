@@ -0,0 +1,192 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gh-standup/internal/llm"
+)
+
+// fakeProvider answers report requests with a canned report per model and
+// judge requests with a canned verdict.
+type fakeProvider struct {
+	reports      map[string]string
+	verdict      string
+	defaultModel string
+	requests     []llm.Request
+}
+
+func (p *fakeProvider) Name() string         { return "fake" }
+func (p *fakeProvider) DefaultModel() string { return p.defaultModel }
+
+func (p *fakeProvider) Complete(ctx context.Context, request llm.Request) (*llm.Response, error) {
+	p.requests = append(p.requests, request)
+	content := p.reports[request.Model]
+	if request.Messages[0].Content == judgeSystemPrompt {
+		content = p.verdict
+	}
+	return &llm.Response{Choices: []llm.Choice{{Message: llm.Message{Role: "assistant", Content: content}}}}, nil
+}
+
+func testConfig(evaluators ...llm.Evaluator) *llm.PromptConfig {
+	return &llm.PromptConfig{
+		Model: "prompt-model",
+		Messages: []llm.PromptMessage{
+			{Role: "user", Content: "Write a standup:\n{{activities}}"},
+		},
+		TestData:   []map[string]string{{"activities": "COMMITS:\n- [my-app] Add login"}},
+		Evaluators: evaluators,
+	}
+}
+
+func TestRunAppliesEvaluators(t *testing.T) {
+	provider := &fakeProvider{reports: map[string]string{
+		"good-model": "Added login to my-app.",
+		"bad-model":  "# Report\nDid stuff.",
+	}}
+	config := testConfig(
+		llm.Evaluator{Name: "mentions project", String: &llm.StringEvaluator{Contains: "my-app"}},
+		llm.Evaluator{Name: "no headers", Uses: UsesNoMarkdownHeaders},
+		llm.Evaluator{Name: "short", MaxLength: 100},
+		llm.Evaluator{Name: "sentence", Regex: `\.$`},
+	)
+
+	runner := &Runner{Provider: provider}
+	results, err := runner.Run(context.Background(), config, []string{"good-model", "bad-model"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected a result per model, got %d", len(results))
+	}
+
+	if !results[0].Passed() {
+		t.Errorf("Expected the good report to pass, got %+v", results[0].Outcomes)
+	}
+	if results[1].Passed() {
+		t.Error("Expected the bad report to fail")
+	}
+	want := []bool{false, false, true, true}
+	for i, outcome := range results[1].Outcomes {
+		if outcome.Passed != want[i] {
+			t.Errorf("Evaluator %q: expected passed=%v, got %+v", outcome.Evaluator, want[i], outcome)
+		}
+	}
+
+	if !strings.Contains(provider.requests[0].Messages[0].Content, "- [my-app] Add login") {
+		t.Errorf("Expected the test data in the prompt, got %q", provider.requests[0].Messages[0].Content)
+	}
+}
+
+func TestRunDefaultsToPromptModel(t *testing.T) {
+	provider := &fakeProvider{reports: map[string]string{"prompt-model": "ok"}}
+	runner := &Runner{Provider: provider}
+
+	results, err := runner.Run(context.Background(), testConfig(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Report != "ok" || provider.requests[0].Model != "prompt-model" {
+		t.Errorf("Expected a run with the prompt's model, got %+v", results)
+	}
+}
+
+func TestRunLLMJudge(t *testing.T) {
+	tests := []struct {
+		verdict string
+		passed  bool
+		reason  string
+	}{
+		{"PASS\nMentions the login work.", true, ""},
+		{"**FAIL**: does not mention the project", false, "does not mention the project"},
+		{"Maybe?", false, "judge gave an unclear answer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.verdict, func(t *testing.T) {
+			provider := &fakeProvider{reports: map[string]string{"model": "Added login."}, verdict: tt.verdict}
+			config := testConfig(llm.Evaluator{Name: "judge", LLM: &llm.LLMEvaluator{Rubric: "Mentions the project"}})
+
+			runner := &Runner{Provider: provider, JudgeModel: "judge-model"}
+			results, err := runner.Run(context.Background(), config, []string{"model"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			outcome := results[0].Outcomes[0]
+			if outcome.Passed != tt.passed || !strings.Contains(outcome.Reason, tt.reason) {
+				t.Errorf("Expected passed=%v with reason %q, got %+v", tt.passed, tt.reason, outcome)
+			}
+			judgeRequest := provider.requests[1]
+			if judgeRequest.Model != "judge-model" || !strings.Contains(judgeRequest.Messages[1].Content, "Rubric:\nMentions the project") {
+				t.Errorf("Unexpected judge request: %+v", judgeRequest)
+			}
+		})
+	}
+}
+
+func TestRunLLMJudgeDefaultsToReportModel(t *testing.T) {
+	provider := &fakeProvider{reports: map[string]string{"prompt-model": "Added login."}, verdict: "PASS", defaultModel: "provider-model"}
+	config := testConfig(llm.Evaluator{Name: "judge", LLM: &llm.LLMEvaluator{Rubric: "Mentions the project"}})
+	config.Path = "team.prompt.yml"
+
+	runner := &Runner{Provider: provider}
+	if _, err := runner.Run(context.Background(), config, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(provider.requests) != 2 || provider.requests[0].Model != "prompt-model" {
+		t.Fatalf("Expected the prompt file's model to write the report, got %+v", provider.requests)
+	}
+	if judgeRequest := provider.requests[1]; judgeRequest.Model != "prompt-model" {
+		t.Errorf("Expected the model that wrote the report to judge it, got %q", judgeRequest.Model)
+	}
+}
+
+func TestRunRejectsInvalidEvaluators(t *testing.T) {
+	tests := []llm.Evaluator{
+		{Name: "nothing"},
+		{Name: "two checks", Regex: "a", MaxLength: 3},
+		{Name: "bad regex", Regex: "("},
+		{Name: "unknown", Uses: "github/similarity"},
+		{Name: "no rubric", LLM: &llm.LLMEvaluator{}},
+	}
+
+	for _, evaluator := range tests {
+		runner := &Runner{Provider: &fakeProvider{}}
+		if _, err := runner.Run(context.Background(), testConfig(evaluator), nil); err == nil {
+			t.Errorf("Expected error for evaluator %q", evaluator.Name)
+		}
+	}
+}
+
+func TestRunRequiresTestData(t *testing.T) {
+	runner := &Runner{Provider: &fakeProvider{}}
+	if _, err := runner.Run(context.Background(), &llm.PromptConfig{}, nil); err == nil {
+		t.Error("Expected error without test data")
+	}
+}
+
+func TestWriteMatrix(t *testing.T) {
+	evaluators := []llm.Evaluator{{Name: "contains"}, {Name: "short"}}
+	results := []Result{
+		{Case: 1, Model: "a", Outcomes: []Outcome{{Evaluator: "contains", Passed: true}, {Evaluator: "short", Passed: true}}},
+		{Case: 1, Model: "b", Outcomes: []Outcome{{Evaluator: "contains", Passed: true}, {Evaluator: "short", Reason: "too long"}}},
+	}
+
+	var out bytes.Buffer
+	if err := WriteMatrix(&out, evaluators, results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "CASE  MODEL  CONTAINS  SHORT\n" +
+		"1     a      PASS      PASS\n" +
+		"1     b      PASS      FAIL\n" +
+		"\n1 of 2 runs passed every evaluator\n" +
+		"- case 1, b, short: too long\n"
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
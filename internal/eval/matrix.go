@@ -0,0 +1,55 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gh-standup/internal/llm"
+)
+
+// WriteMatrix prints a pass/fail table with a row per test case and model and
+// a column per evaluator, followed by the reasons for each failure.
+func WriteMatrix(w io.Writer, evaluators []llm.Evaluator, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"CASE", "MODEL"}
+	for _, evaluator := range evaluators {
+		header = append(header, strings.ToUpper(evaluator.Name))
+	}
+	fmt.Fprintln(table, strings.Join(header, "\t"))
+
+	var failures []string
+	passed := 0
+	for _, result := range results {
+		row := []string{fmt.Sprint(result.Case), modelLabel(result.Model)}
+		if result.Err != nil {
+			for range evaluators {
+				row = append(row, "ERROR")
+			}
+			failures = append(failures, fmt.Sprintf("case %d, %s: %v", result.Case, modelLabel(result.Model), result.Err))
+		}
+		for _, outcome := range result.Outcomes {
+			if outcome.Passed {
+				row = append(row, "PASS")
+				continue
+			}
+			row = append(row, "FAIL")
+			failures = append(failures, fmt.Sprintf("case %d, %s, %s: %s", result.Case, modelLabel(result.Model), outcome.Evaluator, outcome.Reason))
+		}
+		if result.Passed() {
+			passed++
+		}
+		fmt.Fprintln(table, strings.Join(row, "\t"))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%d of %d runs passed every evaluator\n", passed, len(results))
+	for _, failure := range failures {
+		fmt.Fprintf(w, "- %s\n", failure)
+	}
+	return nil
+}
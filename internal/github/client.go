@@ -1,36 +1,98 @@
 package github
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/gh-standup/internal/retry"
 	"github.com/gh-standup/internal/types"
 )
 
 type Client struct {
-	client *api.RESTClient
+	client  *api.RESTClient
+	graphql *api.GraphQLClient
 }
 
-func NewClient() (*Client, error) {
+// NewClient connects to GitHub with the GitHub CLI's credentials. Requests
+// that hit a rate limit or fail transiently are retried according to retryOpts.
+func NewClient(retryOpts retry.Options) (*Client, error) {
 	log.Print("  Connecting to GitHub API... ")
-	client, err := api.DefaultRESTClient()
+	opts := api.ClientOptions{Transport: retry.NewTransport(nil, retryOpts)}
+	client, err := api.NewRESTClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	graphql, err := api.NewGraphQLClient(opts)
 	if err != nil {
 		return nil, err
 	}
 	log.Println("Done")
 
-	return &Client{client: client}, nil
+	return &Client{client: client, graphql: graphql}, nil
+}
+
+// searchIssue is an item returned by the issue search endpoint, which covers
+// both issues and pull requests.
+type searchIssue struct {
+	Number        int    `json:"number"`
+	Title         string `json:"title"`
+	Body          string `json:"body"`
+	State         string `json:"state"`
+	Draft         bool   `json:"draft"`
+	RepositoryURL string `json:"repository_url"`
+	HTMLURL       string `json:"html_url"`
+	User          struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// activity fills in the fields every activity derived from this item shares.
+func (item searchIssue) activity(kind types.ActivityKind) types.GitHubActivity {
+	activity := types.GitHubActivity{
+		Type:        kind,
+		Repository:  repoFromAPIURL(item.RepositoryURL),
+		Description: item.Body,
+		URL:         item.HTMLURL,
+		Number:      item.Number,
+		State:       item.State,
+		Author:      item.User.Login,
+		Draft:       item.Draft,
+	}
+	for _, label := range item.Labels {
+		activity.Labels = append(activity.Labels, label.Name)
+	}
+	if item.PullRequest != nil && item.PullRequest.MergedAt != nil {
+		activity.Merged = true
+		activity.State = "merged"
+	}
+	return activity
+}
+
+// get performs a GET request against the REST API, aborting when ctx is done.
+func (c *Client) get(ctx context.Context, path string, response interface{}) error {
+	return c.client.DoWithContext(ctx, http.MethodGet, path, nil, response)
 }
 
-func (c *Client) GetCurrentUser() (string, error) {
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
 	var user struct {
 		Login string `json:"login"`
 	}
 
-	err := c.client.Get("user", &user)
+	err := c.get(ctx, "user", &user)
 	if err != nil {
 		return "", err
 	}
@@ -38,60 +100,126 @@ func (c *Client) GetCurrentUser() (string, error) {
 	return user.Login, nil
 }
 
-// CollectActivity gathers activity data from GitHub API
-func (c *Client) CollectActivity(username, repo string, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
-	var activities []types.GitHubActivity
+// CollectOptions controls how sources are run by CollectActivity.
+type CollectOptions struct {
+	// Concurrency is the maximum number of sources collected at once.
+	Concurrency int
+	// SourceTimeout bounds each individual source; zero means no limit.
+	SourceTimeout time.Duration
+}
 
-	// Collect commits (may be slow or fail)
-	log.Print("  🔍 Searching for commits... ")
-	commits, err := c.getCommits(username, repo, startDate, endDate)
-	if err != nil {
-		log.Printf("⚠️  Skipped (search may be restricted)\n")
-	} else {
-		log.Printf("✅ Found %d commits\n", len(commits))
-		activities = append(activities, commits...)
+// CollectActivity gathers activity data from GitHub API using the given sources.
+// Sources run concurrently; the result keeps the order in which sources were given.
+func (c *Client) CollectActivity(ctx context.Context, query Query, sources []ActivitySource, opts CollectOptions) ([]types.GitHubActivity, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
 	}
 
-	// Collect pull requests
-	log.Print("  🔍 Searching for pull requests... ")
-	prs, err := c.getPullRequests(username, repo, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get pull requests: %w", err)
+	type result struct {
+		activities []types.GitHubActivity
+		err        error
 	}
-	log.Printf("✅ Found %d pull requests\n", len(prs))
-	activities = append(activities, prs...)
+	results := make([]result, len(sources))
 
-	// Collect issues
-	log.Print("  🔍 Searching for issues... ")
-	issues, err := c.getIssues(username, repo, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get issues: %w", err)
+	labels := make([]string, len(sources))
+	for i, source := range sources {
+		labels[i] = sourceLabel(source)
 	}
-	log.Printf("✅ Found %d issues\n", len(issues))
-	activities = append(activities, issues...)
+	log.Printf("  🔍 Collecting %s...\n", strings.Join(labels, ", "))
+
+	collectCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source ActivitySource) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-collectCtx.Done():
+				results[i].err = collectCtx.Err()
+				return
+			}
+
+			activities, err := c.collectSource(collectCtx, source, query, opts.SourceTimeout)
+			if err != nil {
+				// No point in waiting for the others once a required source failed
+				cancel()
+			}
+			results[i] = result{activities: activities, err: err}
+		}(i, source)
+	}
+	wg.Wait()
 
-	// Collect reviews
-	log.Print("  🔍 Searching for code reviews... ")
-	reviews, err := c.getReviews(username, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var activities []types.GitHubActivity
+	var firstErr error
+	for i, source := range sources {
+		if err := results[i].err; err != nil {
+			// Prefer the failure that caused the cancellation over the cancellations themselves
+			if firstErr == nil || errors.Is(firstErr, context.Canceled) {
+				firstErr = fmt.Errorf("failed to get %s: %w", sourceLabel(source), err)
+			}
+			continue
+		}
+		activities = append(activities, results[i].activities...)
+	}
+	if firstErr != nil {
+		return nil, firstErr
 	}
-	log.Printf("✅ Found %d reviews\n", len(reviews))
-	activities = append(activities, reviews...)
 
 	return activities, nil
 }
 
-func (c *Client) getCommits(username, repo string, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+// collectSource runs a single source, turning timeouts and failures of
+// optional sources into warnings.
+func (c *Client) collectSource(ctx context.Context, source ActivitySource, query Query, timeout time.Duration) ([]types.GitHubActivity, error) {
+	sourceCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		sourceCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	activities, err := source.Collect(sourceCtx, query)
+	switch {
+	case err == nil:
+		log.Printf("  ✅ Found %d %s\n", len(activities), sourceLabel(source))
+		return activities, nil
+	case ctx.Err() != nil:
+		// The whole collection was cancelled, not just this source
+		return nil, ctx.Err()
+	case errors.Is(err, context.DeadlineExceeded):
+		log.Printf("  ⚠️  Skipped %s (timed out after %s)\n", sourceLabel(source), timeout)
+		return nil, nil
+	case isOptional(source):
+		log.Printf("  ⚠️  Skipped %s (%v)\n", sourceLabel(source), err)
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func sourceLabel(source ActivitySource) string {
+	return strings.ReplaceAll(source.Name(), "_", " ")
+}
+
+func (c *Client) getCommits(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
 	var activities []types.GitHubActivity
 
 	// Base query for commits search
-	baseQuery := fmt.Sprintf("author:%s committer-date:%s..%s",
-		username, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	baseQuery := fmt.Sprintf("author:%s committer-date:%s",
+		username, searchRange(startDate, endDate))
 
-	if repo != "" {
-		baseQuery += fmt.Sprintf(" repo:%s", repo)
-	}
+	baseQuery += repos.qualifiers()
 
 	escapedQuery := strings.ReplaceAll(baseQuery, " ", "%20")
 
@@ -102,11 +230,14 @@ func (c *Client) getCommits(username, repo string, startDate, endDate time.Time)
 			Repository struct {
 				FullName string `json:"full_name"`
 			} `json:"repository"`
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
 			Commit struct {
-				Message string `json:"message"`
-				Author  struct {
+				Message   string `json:"message"`
+				Committer struct {
 					Date time.Time `json:"date"`
-				} `json:"author"`
+				} `json:"committer"`
 			} `json:"commit"`
 			HTMLURL string `json:"html_url"`
 		} `json:"items"`
@@ -119,7 +250,7 @@ func (c *Client) getCommits(username, repo string, startDate, endDate time.Time)
 	for {
 		// Build query with pagination
 
-		err := c.client.Get(fmt.Sprintf("search/commits?q=%s&per_page=%d&page=%d&sort=committer-date&order=desc", escapedQuery, perPage, page), &searchResult)
+		err := c.get(ctx, fmt.Sprintf("search/commits?q=%s&per_page=%d&page=%d&sort=committer-date&order=desc", escapedQuery, perPage, page), &searchResult)
 		if err != nil {
 			// Return error so caller knows commits search failed
 			return activities, fmt.Errorf("commits search failed (this is common due to GitHub API restrictions): %w", err)
@@ -132,14 +263,23 @@ func (c *Client) getCommits(username, repo string, startDate, endDate time.Time)
 
 		// Add items from current page
 		for _, item := range searchResult.Items {
-			activities = append(activities, types.GitHubActivity{
-				Type:        "commit",
+			// The search and the report go by committer date, which rebased commits do not share with their author date
+			if !inWindow(item.Commit.Committer.Date, startDate, endDate) || !repos.Match(item.Repository.FullName) {
+				continue
+			}
+			activity := types.GitHubActivity{
+				Type:        types.KindCommit,
 				Repository:  item.Repository.FullName,
 				Title:       strings.Split(item.Commit.Message, "\n")[0],
 				Description: item.Commit.Message,
 				URL:         item.HTMLURL,
-				CreatedAt:   item.Commit.Author.Date,
-			})
+				CreatedAt:   item.Commit.Committer.Date,
+				SHA:         item.SHA,
+			}
+			if item.Author != nil {
+				activity.Author = item.Author.Login
+			}
+			activities = append(activities, activity)
 		}
 
 		// If we got less than perPage items, we've reached the end
@@ -159,31 +299,19 @@ func (c *Client) getCommits(username, repo string, startDate, endDate time.Time)
 	return activities, nil
 }
 
-func (c *Client) getPullRequests(username, repo string, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+func (c *Client) getPullRequests(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
 	var activities []types.GitHubActivity
 
 	// Base query for pull requests search
-	baseQuery := fmt.Sprintf("author:%s created:%s..%s",
-		username, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	baseQuery := fmt.Sprintf("author:%s created:%s",
+		username, searchRange(startDate, endDate))
 
-	if repo != "" {
-		baseQuery += fmt.Sprintf(" repo:%s", repo)
-	}
+	baseQuery += repos.qualifiers()
 
 	escapedQuery := strings.ReplaceAll(baseQuery, " ", "%20")
 
 	var searchResult struct {
-		Items []struct {
-			Number     int    `json:"number"`
-			Title      string `json:"title"`
-			Body       string `json:"body"`
-			State      string `json:"state"`
-			Repository struct {
-				FullName string `json:"full_name"`
-			} `json:"repository"`
-			HTMLURL   string    `json:"html_url"`
-			CreatedAt time.Time `json:"created_at"`
-		} `json:"items"`
+		Items []searchIssue `json:"items"`
 	}
 
 	// Pagination to get all pull requests
@@ -191,7 +319,7 @@ func (c *Client) getPullRequests(username, repo string, startDate, endDate time.
 	perPage := 100
 
 	for {
-		err := c.client.Get(fmt.Sprintf("search/issues?q=%s+type:pr&per_page=%d&page=%d&sort=created&order=desc", escapedQuery, perPage, page), &searchResult)
+		err := c.get(ctx, fmt.Sprintf("search/issues?q=%s+type:pr&per_page=%d&page=%d&sort=created&order=desc", escapedQuery, perPage, page), &searchResult)
 		if err != nil {
 			return activities, err
 		}
@@ -203,14 +331,13 @@ func (c *Client) getPullRequests(username, repo string, startDate, endDate time.
 
 		// Add items from current page
 		for _, item := range searchResult.Items {
-			activities = append(activities, types.GitHubActivity{
-				Type:        "pull_request",
-				Repository:  item.Repository.FullName,
-				Title:       fmt.Sprintf("PR #%d: %s", item.Number, item.Title),
-				Description: item.Body,
-				URL:         item.HTMLURL,
-				CreatedAt:   item.CreatedAt,
-			})
+			if !inWindow(item.CreatedAt, startDate, endDate) || !repos.Match(repoFromAPIURL(item.RepositoryURL)) {
+				continue
+			}
+			activity := item.activity(types.KindPullRequest)
+			activity.Title = fmt.Sprintf("PR #%d: %s", item.Number, item.Title)
+			activity.CreatedAt = item.CreatedAt
+			activities = append(activities, activity)
 		}
 
 		// If we got less than perPage items, we've reached the end
@@ -230,31 +357,19 @@ func (c *Client) getPullRequests(username, repo string, startDate, endDate time.
 	return activities, nil
 }
 
-func (c *Client) getIssues(username, repo string, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+func (c *Client) getIssues(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
 	var activities []types.GitHubActivity
 
 	// Base query for issues search
-	baseQuery := fmt.Sprintf("author:%s created:%s..%s",
-		username, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	baseQuery := fmt.Sprintf("author:%s created:%s",
+		username, searchRange(startDate, endDate))
 
-	if repo != "" {
-		baseQuery += fmt.Sprintf(" repo:%s", repo)
-	}
+	baseQuery += repos.qualifiers()
 
 	escapedQuery := strings.ReplaceAll(baseQuery, " ", "%20")
 
 	var searchResult struct {
-		Items []struct {
-			Number     int    `json:"number"`
-			Title      string `json:"title"`
-			Body       string `json:"body"`
-			State      string `json:"state"`
-			Repository struct {
-				FullName string `json:"full_name"`
-			} `json:"repository"`
-			HTMLURL   string    `json:"html_url"`
-			CreatedAt time.Time `json:"created_at"`
-		} `json:"items"`
+		Items []searchIssue `json:"items"`
 	}
 
 	// Pagination to get all issues
@@ -262,7 +377,7 @@ func (c *Client) getIssues(username, repo string, startDate, endDate time.Time)
 	perPage := 100
 
 	for {
-		err := c.client.Get(fmt.Sprintf("search/issues?q=%s+type:issue&per_page=%d&page=%d&sort=created&order=desc", escapedQuery, perPage, page), &searchResult)
+		err := c.get(ctx, fmt.Sprintf("search/issues?q=%s+type:issue&per_page=%d&page=%d&sort=created&order=desc", escapedQuery, perPage, page), &searchResult)
 		if err != nil {
 			return activities, err
 		}
@@ -274,14 +389,13 @@ func (c *Client) getIssues(username, repo string, startDate, endDate time.Time)
 
 		// Add items from current page
 		for _, item := range searchResult.Items {
-			activities = append(activities, types.GitHubActivity{
-				Type:        "issue",
-				Repository:  item.Repository.FullName,
-				Title:       fmt.Sprintf("Issue #%d: %s", item.Number, item.Title),
-				Description: item.Body,
-				URL:         item.HTMLURL,
-				CreatedAt:   item.CreatedAt,
-			})
+			if !inWindow(item.CreatedAt, startDate, endDate) || !repos.Match(repoFromAPIURL(item.RepositoryURL)) {
+				continue
+			}
+			activity := item.activity(types.KindIssue)
+			activity.Title = fmt.Sprintf("Issue #%d: %s", item.Number, item.Title)
+			activity.CreatedAt = item.CreatedAt
+			activities = append(activities, activity)
 		}
 
 		// If we got less than perPage items, we've reached the end
@@ -300,68 +414,3 @@ func (c *Client) getIssues(username, repo string, startDate, endDate time.Time)
 
 	return activities, nil
 }
-
-func (c *Client) getReviews(username string, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
-	var activities []types.GitHubActivity
-
-	// Base query for pull requests reviewed by user
-	baseQuery := fmt.Sprintf("reviewed-by:%s created:%s..%s",
-		username, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
-
-	escapedQuery := strings.ReplaceAll(baseQuery, " ", "%20")
-
-	var searchResult struct {
-		Items []struct {
-			Number     int    `json:"number"`
-			Title      string `json:"title"`
-			Repository struct {
-				FullName string `json:"full_name"`
-			} `json:"repository"`
-			HTMLURL   string    `json:"html_url"`
-			CreatedAt time.Time `json:"created_at"`
-		} `json:"items"`
-	}
-
-	// Pagination to get all reviews
-	page := 1
-	perPage := 100
-
-	for {
-		err := c.client.Get(fmt.Sprintf("search/issues?q=%s+type:pr&per_page=%d&page=%d&sort=created&order=desc", escapedQuery, perPage, page), &searchResult)
-		if err != nil {
-			return activities, err
-		}
-
-		// If no items returned, we've reached the end
-		if len(searchResult.Items) == 0 {
-			break
-		}
-
-		// Add items from current page
-		for _, item := range searchResult.Items {
-			activities = append(activities, types.GitHubActivity{
-				Type:        "review",
-				Repository:  item.Repository.FullName,
-				Title:       fmt.Sprintf("Reviewed PR #%d: %s", item.Number, item.Title),
-				Description: fmt.Sprintf("Reviewed pull request: %s", item.Title),
-				URL:         item.HTMLURL,
-				CreatedAt:   item.CreatedAt,
-			})
-		}
-
-		// If we got less than perPage items, we've reached the end
-		if len(searchResult.Items) < perPage {
-			break
-		}
-
-		// Move to next page
-		page++
-
-		// Safety check to prevent infinite loops
-		if page > 10 { // Max 1000 reviews (100 * 10 pages)
-			break
-		}
-	}
-
-	return activities, nil
-}
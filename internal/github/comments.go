@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// commentExcerptLength is the maximum number of characters kept from a comment body.
+const commentExcerptLength = 200
+
+type comment struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// getComments finds issue comments, pull request conversation comments and
+// inline review comments written by the user within the window.
+func (c *Client) getComments(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+	var activities []types.GitHubActivity
+
+	// A new comment bumps the parent's updated_at, so older issues cannot contain one
+	baseQuery := fmt.Sprintf("commenter:%s updated:>=%s",
+		username, searchTime(startDate))
+
+	baseQuery += repos.qualifiers()
+
+	var searchResult struct {
+		Items []searchIssue `json:"items"`
+	}
+
+	// Pagination to get all commented issues and pull requests
+	page := 1
+	perPage := 100
+
+	for {
+		err := c.get(ctx, fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d&sort=updated&order=desc", url.QueryEscape(baseQuery), perPage, page), &searchResult)
+		if err != nil {
+			return activities, err
+		}
+
+		// If no items returned, we've reached the end
+		if len(searchResult.Items) == 0 {
+			break
+		}
+
+		for _, item := range searchResult.Items {
+			itemRepo := repoFromAPIURL(item.RepositoryURL)
+			if !repos.Match(itemRepo) {
+				continue
+			}
+			isPullRequest := item.PullRequest != nil
+
+			endpoints := []string{fmt.Sprintf("repos/%s/issues/%d/comments", itemRepo, item.Number)}
+			if isPullRequest {
+				endpoints = append(endpoints, fmt.Sprintf("repos/%s/pulls/%d/comments", itemRepo, item.Number))
+			}
+
+			title := fmt.Sprintf("Commented on issue #%d: %s", item.Number, item.Title)
+			if isPullRequest {
+				title = fmt.Sprintf("Commented on PR #%d: %s", item.Number, item.Title)
+			}
+
+			for i, endpoint := range endpoints {
+				comments, err := c.getUserComments(ctx, endpoint, username, startDate, endDate)
+				if err != nil {
+					return activities, fmt.Errorf("failed to list comments of %s#%d: %w", itemRepo, item.Number, err)
+				}
+
+				for _, comment := range comments {
+					activity := item.activity(types.KindComment)
+					activity.Title = title
+					activity.Description = excerpt(comment.Body, commentExcerptLength)
+					activity.CreatedAt = comment.CreatedAt
+					activity.Comment = &types.CommentDetail{
+						URL:           comment.HTMLURL,
+						OnPullRequest: isPullRequest,
+						// The second endpoint lists inline review comments
+						Inline: i == 1,
+					}
+					activities = append(activities, activity)
+				}
+			}
+		}
+
+		// If we got less than perPage items, we've reached the end
+		if len(searchResult.Items) < perPage {
+			break
+		}
+
+		// Move to next page
+		page++
+
+		// Safety check to prevent infinite loops
+		if page > 10 { // Max 1000 issues and pull requests (100 * 10 pages)
+			break
+		}
+	}
+
+	return activities, nil
+}
+
+// getUserComments lists a comments endpoint and keeps the user's comments created within the window.
+func (c *Client) getUserComments(ctx context.Context, endpoint, username string, startDate, endDate time.Time) ([]comment, error) {
+	var matching []comment
+
+	page := 1
+	perPage := 100
+	// since filters on updated_at, which is never earlier than created_at
+	since := url.QueryEscape(startDate.UTC().Format(time.RFC3339))
+
+	for {
+		var comments []comment
+		err := c.get(ctx, fmt.Sprintf("%s?since=%s&per_page=%d&page=%d", endpoint, since, perPage, page), &comments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range comments {
+			if !strings.EqualFold(comment.User.Login, username) {
+				continue
+			}
+			if !inWindow(comment.CreatedAt, startDate, endDate) {
+				continue
+			}
+			matching = append(matching, comment)
+		}
+
+		if len(comments) < perPage || page >= 10 {
+			break
+		}
+		page++
+	}
+
+	return matching, nil
+}
+
+// excerpt collapses whitespace in text and truncates it to at most max characters.
+func excerpt(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:max-1])) + "…"
+}
+
+func init() {
+	registerSearchSource("comments", false, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getComments(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+}
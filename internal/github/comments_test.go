@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+func TestGetComments(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/search/issues": `{"items":[
+			{"number":3,"title":"Crash on start","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/issues/3"},
+			{"number":7,"title":"Add cache","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/pull/7","pull_request":{}}
+		]}`,
+		"/repos/octo/hello/issues/3/comments": `[
+			{"user":{"login":"octocat"},"body":"Cannot reproduce\non main","html_url":"https://github.com/octo/hello/issues/3#issuecomment-1","created_at":"2024-01-02T09:00:00Z"},
+			{"user":{"login":"hubot"},"body":"Still broken","html_url":"https://github.com/octo/hello/issues/3#issuecomment-2","created_at":"2024-01-02T10:00:00Z"}
+		]`,
+		"/repos/octo/hello/issues/7/comments": `[
+			{"user":{"login":"octocat"},"body":"Edited today","html_url":"https://github.com/octo/hello/pull/7#issuecomment-3","created_at":"2023-12-20T09:00:00Z"},
+			{"user":{"login":"octocat"},"body":"Ready for review","html_url":"https://github.com/octo/hello/pull/7#issuecomment-4","created_at":"2024-01-02T11:00:00Z"}
+		]`,
+		"/repos/octo/hello/pulls/7/comments": `[
+			{"user":{"login":"octocat"},"body":"Off by one","html_url":"https://github.com/octo/hello/pull/7#discussion_r5","created_at":"2024-01-02T12:00:00Z"}
+		]`,
+	})
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	activities, err := client.getComments(context.Background(), "octocat", RepoFilter{}, start, end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 3 {
+		t.Fatalf("Expected the user's 3 comments in the window, got %d: %+v", len(activities), activities)
+	}
+
+	tests := []struct {
+		title       string
+		url         string
+		description string
+		detail      types.CommentDetail
+	}{
+		{
+			title:       "Commented on issue #3: Crash on start",
+			url:         "https://github.com/octo/hello/issues/3",
+			description: "Cannot reproduce on main",
+			detail:      types.CommentDetail{URL: "https://github.com/octo/hello/issues/3#issuecomment-1"},
+		},
+		{
+			title:       "Commented on PR #7: Add cache",
+			url:         "https://github.com/octo/hello/pull/7",
+			description: "Ready for review",
+			detail:      types.CommentDetail{URL: "https://github.com/octo/hello/pull/7#issuecomment-4", OnPullRequest: true},
+		},
+		{
+			title:       "Commented on PR #7: Add cache",
+			url:         "https://github.com/octo/hello/pull/7",
+			description: "Off by one",
+			detail:      types.CommentDetail{URL: "https://github.com/octo/hello/pull/7#discussion_r5", OnPullRequest: true, Inline: true},
+		},
+	}
+
+	for i, expected := range tests {
+		activity := activities[i]
+		if activity.Type != types.KindComment || activity.Repository != "octo/hello" {
+			t.Errorf("Unexpected comment activity: %+v", activity)
+		}
+		if activity.Title != expected.title || activity.URL != expected.url || activity.Description != expected.description {
+			t.Errorf("Expected %q linking to %s with %q, got %q linking to %s with %q",
+				expected.title, expected.url, expected.description, activity.Title, activity.URL, activity.Description)
+		}
+		if activity.Comment == nil || *activity.Comment != expected.detail {
+			t.Errorf("Expected comment detail %+v, got %+v", expected.detail, activity.Comment)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	if got := excerpt("  Looks\n\ngood   to me ", 200); got != "Looks good to me" {
+		t.Errorf("Expected whitespace to be collapsed, got %q", got)
+	}
+
+	if got := excerpt("abcdefghij", 5); got != "abcd…" {
+		t.Errorf("Expected truncated excerpt, got %q", got)
+	}
+}
@@ -0,0 +1,319 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+const (
+	// eventsRetention is how far back the events API reaches.
+	eventsRetention = 90 * 24 * time.Hour
+	// eventsMaxPages is the number of pages the events API serves at most with per_page=100.
+	eventsMaxPages = 3
+)
+
+type event struct {
+	Type string `json:"type"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type eventIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	Draft   bool   `json:"draft"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Additions   int       `json:"additions"`
+	Deletions   int       `json:"deletions"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// fill copies the fields shared by all activities about this issue or pull request.
+func (issue *eventIssue) fill(activity *types.GitHubActivity) {
+	activity.Number = issue.Number
+	activity.State = issue.State
+	activity.Author = issue.User.Login
+	activity.Draft = issue.Draft
+	activity.Merged = issue.Merged
+	activity.Additions = issue.Additions
+	activity.Deletions = issue.Deletions
+	for _, label := range issue.Labels {
+		activity.Labels = append(activity.Labels, label.Name)
+	}
+	if issue.Merged {
+		activity.State = "merged"
+	}
+}
+
+type eventComment struct {
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+}
+
+type eventPayload struct {
+	Action string `json:"action"`
+	// PushEvent
+	Ref     string `json:"ref"`
+	Commits []struct {
+		SHA      string `json:"sha"`
+		Message  string `json:"message"`
+		Distinct bool   `json:"distinct"`
+	} `json:"commits"`
+	// CreateEvent
+	RefType string `json:"ref_type"`
+	// PullRequestEvent, PullRequestReviewEvent, PullRequestReviewCommentEvent
+	PullRequest *eventIssue `json:"pull_request"`
+	Review      *struct {
+		State       string    `json:"state"`
+		HTMLURL     string    `json:"html_url"`
+		SubmittedAt time.Time `json:"submitted_at"`
+	} `json:"review"`
+	// IssuesEvent, IssueCommentEvent
+	Issue   *eventIssue   `json:"issue"`
+	Comment *eventComment `json:"comment"`
+	// ReleaseEvent
+	Release *struct {
+		Name       string `json:"name"`
+		TagName    string `json:"tag_name"`
+		Body       string `json:"body"`
+		HTMLURL    string `json:"html_url"`
+		Prerelease bool   `json:"prerelease"`
+	} `json:"release"`
+}
+
+// eventsSource collects activity from the events feed.
+type eventsSource struct {
+	client *Client
+}
+
+func (s *eventsSource) Name() string { return "events" }
+
+func (s *eventsSource) Collect(ctx context.Context, query Query) ([]types.GitHubActivity, error) {
+	if time.Since(query.StartDate) > eventsRetention {
+		log.Println("  ℹ️  Window is older than the events retention, falling back to search")
+		return s.fallback(ctx, query)
+	}
+
+	var activities []types.GitHubActivity
+	complete := false
+
+	for page := 1; page <= eventsMaxPages; page++ {
+		var events []event
+		err := s.client.get(ctx, fmt.Sprintf("users/%s/events?per_page=100&page=%d", query.Username, page), &events)
+		if err != nil {
+			return nil, fmt.Errorf("events request failed: %w", err)
+		}
+
+		for _, e := range events {
+			if e.CreatedAt.Before(query.StartDate) {
+				// Events are returned newest first, so everything else is older
+				complete = true
+				break
+			}
+			if e.CreatedAt.After(query.EndDate) {
+				continue
+			}
+			if !query.Repos.Match(e.Repo.Name) {
+				continue
+			}
+			activities = append(activities, eventToActivities(e)...)
+		}
+
+		if complete || len(events) < 100 {
+			complete = true
+			break
+		}
+	}
+
+	if !complete {
+		log.Println("  ℹ️  Events feed does not reach back to the start of the window, falling back to search")
+		return s.fallback(ctx, query)
+	}
+
+	return activities, nil
+}
+
+// fallback collects the window with the search backend's default sources.
+func (s *eventsSource) fallback(ctx context.Context, query Query) ([]types.GitHubActivity, error) {
+	sources, err := s.client.Sources(DefaultSourceNames(BackendSearch))
+	if err != nil {
+		return nil, err
+	}
+	return s.client.CollectActivity(ctx, query, sources, CollectOptions{Concurrency: 1})
+}
+
+// eventToActivities maps a single event to the activities it represents.
+// Events that are not interesting for a standup map to nothing.
+func eventToActivities(e event) []types.GitHubActivity {
+	var payload eventPayload
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return nil
+	}
+
+	repo := e.Repo.Name
+	activity := types.GitHubActivity{
+		Repository: repo,
+		CreatedAt:  e.CreatedAt,
+	}
+
+	switch e.Type {
+	case "PushEvent":
+		var activities []types.GitHubActivity
+		for _, commit := range payload.Commits {
+			if !commit.Distinct {
+				continue
+			}
+			activity.Type = types.KindCommit
+			activity.Title = strings.Split(commit.Message, "\n")[0]
+			activity.Description = commit.Message
+			activity.URL = fmt.Sprintf("https://github.com/%s/commit/%s", repo, commit.SHA)
+			activity.SHA = commit.SHA
+			activities = append(activities, activity)
+		}
+		if len(activities) == 0 && len(payload.Commits) == 0 {
+			// The feed no longer always lists the commits of a push
+			branch := strings.TrimPrefix(payload.Ref, "refs/heads/")
+			activity.Type = types.KindCommit
+			activity.Title = fmt.Sprintf("Pushed to %s", branch)
+			activity.Description = activity.Title
+			activity.URL = fmt.Sprintf("https://github.com/%s/tree/%s", repo, branch)
+			activities = append(activities, activity)
+		}
+		return activities
+
+	case "PullRequestEvent":
+		pr := payload.PullRequest
+		if pr == nil {
+			return nil
+		}
+		pr.fill(&activity)
+		activity.Title = fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title)
+		activity.URL = pr.HTMLURL
+		switch {
+		case payload.Action == "opened":
+			activity.Type = types.KindPullRequest
+			activity.Description = pr.Body
+		case payload.Action == "closed" && pr.Merged:
+			activity.Type = types.KindPullRequestEvent
+			activity.Description = describeTransition(types.ActionMerged)
+			activity.PullRequestEvent = &types.PullRequestEventDetail{Action: types.ActionMerged}
+		case payload.Action == "closed" || payload.Action == "reopened":
+			activity.Type = types.KindPullRequestEvent
+			action := types.PullRequestAction(payload.Action)
+			activity.Description = describeTransition(action)
+			activity.PullRequestEvent = &types.PullRequestEventDetail{Action: action}
+		default:
+			return nil
+		}
+
+	case "PullRequestReviewEvent":
+		pr := payload.PullRequest
+		if pr == nil || payload.Review == nil {
+			return nil
+		}
+		pr.fill(&activity)
+		activity.Type = types.KindReview
+		activity.Title = fmt.Sprintf("Reviewed PR #%d: %s", pr.Number, pr.Title)
+		activity.Description = fmt.Sprintf("Reviewed pull request: %s", pr.Title)
+		activity.URL = payload.Review.HTMLURL
+		activity.Review = &types.ReviewDetail{State: strings.ToUpper(payload.Review.State)}
+		if !payload.Review.SubmittedAt.IsZero() {
+			activity.CreatedAt = payload.Review.SubmittedAt
+		}
+
+	case "PullRequestReviewCommentEvent":
+		pr := payload.PullRequest
+		if pr == nil || payload.Comment == nil {
+			return nil
+		}
+		pr.fill(&activity)
+		activity.Type = types.KindComment
+		activity.Title = fmt.Sprintf("Commented on PR #%d: %s", pr.Number, pr.Title)
+		activity.Description = excerpt(payload.Comment.Body, commentExcerptLength)
+		activity.URL = pr.HTMLURL
+		activity.Comment = &types.CommentDetail{URL: payload.Comment.HTMLURL, OnPullRequest: true, Inline: true}
+
+	case "IssueCommentEvent":
+		issue := payload.Issue
+		if issue == nil || payload.Comment == nil {
+			return nil
+		}
+		issue.fill(&activity)
+		activity.Type = types.KindComment
+		activity.Title = fmt.Sprintf("Commented on issue #%d: %s", issue.Number, issue.Title)
+		if issue.PullRequest != nil {
+			activity.Title = fmt.Sprintf("Commented on PR #%d: %s", issue.Number, issue.Title)
+		}
+		activity.Description = excerpt(payload.Comment.Body, commentExcerptLength)
+		activity.URL = issue.HTMLURL
+		activity.Comment = &types.CommentDetail{URL: payload.Comment.HTMLURL, OnPullRequest: issue.PullRequest != nil}
+
+	case "IssuesEvent":
+		issue := payload.Issue
+		if issue == nil || payload.Action != "opened" {
+			return nil
+		}
+		issue.fill(&activity)
+		activity.Type = types.KindIssue
+		activity.Title = fmt.Sprintf("Issue #%d: %s", issue.Number, issue.Title)
+		activity.Description = issue.Body
+		activity.URL = issue.HTMLURL
+
+	case "CreateEvent":
+		activity.Type = types.KindCreate
+		activity.URL = "https://github.com/" + repo
+		switch payload.RefType {
+		case "repository":
+			activity.Title = fmt.Sprintf("Created repository %s", repo)
+		case "branch", "tag":
+			activity.Title = fmt.Sprintf("Created %s %s", payload.RefType, payload.Ref)
+		default:
+			return nil
+		}
+		activity.Description = activity.Title
+
+	case "ReleaseEvent":
+		release := payload.Release
+		if release == nil || payload.Action != "published" {
+			return nil
+		}
+		name := release.Name
+		if name == "" {
+			name = release.TagName
+		}
+		activity.Type = types.KindRelease
+		activity.Title = fmt.Sprintf("Released %s", name)
+		activity.Description = release.Body
+		activity.URL = release.HTMLURL
+		activity.Release = &types.ReleaseDetail{TagName: release.TagName, Prerelease: release.Prerelease}
+
+	default:
+		return nil
+	}
+
+	return []types.GitHubActivity{activity}
+}
+
+func init() {
+	RegisterSource("events", func(c *Client) ActivitySource {
+		return &eventsSource{client: c}
+	}, BackendEvents)
+}
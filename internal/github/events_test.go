@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+func parseEvent(t *testing.T, raw string) event {
+	t.Helper()
+	var e event
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		t.Fatalf("Failed to parse event: %v", err)
+	}
+	return e
+}
+
+func TestEventToActivitiesPush(t *testing.T) {
+	e := parseEvent(t, `{
+		"type": "PushEvent",
+		"repo": {"name": "test/repo"},
+		"created_at": "2024-05-02T10:00:00Z",
+		"payload": {
+			"ref": "refs/heads/main",
+			"commits": [
+				{"sha": "abc", "message": "Fix bug\n\nDetails", "distinct": true},
+				{"sha": "def", "message": "Already pushed", "distinct": false}
+			]
+		}
+	}`)
+
+	activities := eventToActivities(e)
+	if len(activities) != 1 {
+		t.Fatalf("Expected 1 activity, got %d", len(activities))
+	}
+	if activities[0].Type != "commit" || activities[0].Title != "Fix bug" {
+		t.Errorf("Unexpected commit activity: %+v", activities[0])
+	}
+	if activities[0].URL != "https://github.com/test/repo/commit/abc" {
+		t.Errorf("Unexpected commit URL: %q", activities[0].URL)
+	}
+}
+
+func TestEventToActivitiesMergedPullRequest(t *testing.T) {
+	e := parseEvent(t, `{
+		"type": "PullRequestEvent",
+		"repo": {"name": "test/repo"},
+		"created_at": "2024-05-02T10:00:00Z",
+		"payload": {
+			"action": "closed",
+			"pull_request": {"number": 7, "title": "Add feature", "html_url": "https://github.com/test/repo/pull/7", "state": "closed", "merged": true}
+		}
+	}`)
+
+	activities := eventToActivities(e)
+	if len(activities) != 1 {
+		t.Fatalf("Expected 1 activity, got %d", len(activities))
+	}
+	if activities[0].Type != "pull_request_event" || activities[0].PullRequestEvent == nil || activities[0].PullRequestEvent.Action != types.ActionMerged {
+		t.Errorf("Expected a merged pull request event, got %+v", activities[0])
+	}
+}
+
+func TestEventToActivitiesReview(t *testing.T) {
+	e := parseEvent(t, `{
+		"type": "PullRequestReviewEvent",
+		"repo": {"name": "test/repo"},
+		"created_at": "2024-05-02T10:00:00Z",
+		"payload": {
+			"action": "created",
+			"review": {"state": "approved", "html_url": "https://github.com/test/repo/pull/7#pullrequestreview-1", "submitted_at": "2024-05-02T09:59:00Z"},
+			"pull_request": {"number": 7, "title": "Add feature", "html_url": "https://github.com/test/repo/pull/7"}
+		}
+	}`)
+
+	activities := eventToActivities(e)
+	if len(activities) != 1 {
+		t.Fatalf("Expected 1 activity, got %d", len(activities))
+	}
+	if activities[0].Type != "review" || activities[0].Review == nil || activities[0].Review.State != "APPROVED" {
+		t.Errorf("Expected an approved review, got %+v", activities[0])
+	}
+}
+
+func TestEventToActivitiesIgnoresUninterestingEvents(t *testing.T) {
+	e := parseEvent(t, `{"type": "WatchEvent", "repo": {"name": "test/repo"}, "payload": {"action": "started"}}`)
+
+	if activities := eventToActivities(e); len(activities) != 0 {
+		t.Errorf("Expected no activities, got %+v", activities)
+	}
+}
+
+// searchFallbackCommit is the only activity the fake search API knows about.
+const searchFallbackCommit = `{"items":[{"sha":"abc123","repository":{"full_name":"octo/hello"},"commit":{"message":"Found by search","committer":{"date":"%s"}},"html_url":"https://github.com/octo/hello/commit/abc123"}]}`
+
+// newEventsTestClient serves the events feed with feed, which returns the
+// events of a page, and answers searches with searchFallbackCommit at
+// commitDate. It returns the paths requested.
+func newEventsTestClient(t *testing.T, feed func(page string) string, commitDate time.Time) (*Client, func() []string) {
+	var mu sync.Mutex
+	var paths []string
+	client := newTestClientWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/octocat/events":
+			w.Write([]byte(feed(r.URL.Query().Get("page"))))
+		case "/search/commits":
+			fmt.Fprintf(w, searchFallbackCommit, commitDate.UTC().Format(time.RFC3339))
+		case "/search/issues":
+			w.Write([]byte(`{"items":[]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func countPaths(paths []string, path string) int {
+	count := 0
+	for _, p := range paths {
+		if p == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestEventsSourceFallsBackForOldWindow(t *testing.T) {
+	endDate := time.Now().Add(-100 * 24 * time.Hour)
+	startDate := endDate.Add(-24 * time.Hour)
+	client, requested := newEventsTestClient(t, func(string) string {
+		return `[]`
+	}, endDate.Add(-time.Hour))
+
+	source := &eventsSource{client: client}
+	activities, err := source.Collect(context.Background(), Query{Username: "octocat", StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	paths := requested()
+	if countPaths(paths, "/users/octocat/events") != 0 || countPaths(paths, "/search/commits") != 1 {
+		t.Errorf("Expected search instead of the events feed, got requests %v", paths)
+	}
+	if len(activities) != 1 || activities[0].Title != "Found by search" {
+		t.Errorf("Expected the activity found by search, got %+v", activities)
+	}
+}
+
+func TestEventsSourceFallsBackWhenFeedIsExhausted(t *testing.T) {
+	endDate := time.Now()
+	startDate := endDate.Add(-7 * 24 * time.Hour)
+
+	// Every page is full of events within the window, so the feed never reaches its start
+	recent := fmt.Sprintf(`{"type":"WatchEvent","repo":{"name":"octo/hello"},"payload":{},"created_at":"%s"}`, endDate.Add(-time.Hour).UTC().Format(time.RFC3339))
+	fullPage := "[" + strings.TrimSuffix(strings.Repeat(recent+",", 100), ",") + "]"
+	client, requested := newEventsTestClient(t, func(string) string {
+		return fullPage
+	}, endDate.Add(-2*time.Hour))
+
+	source := &eventsSource{client: client}
+	activities, err := source.Collect(context.Background(), Query{Username: "octocat", StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	paths := requested()
+	if got := countPaths(paths, "/users/octocat/events"); got != eventsMaxPages {
+		t.Errorf("Expected all %d pages of the feed to be read, got %d", eventsMaxPages, got)
+	}
+	if countPaths(paths, "/search/commits") != 1 {
+		t.Errorf("Expected a fallback to search, got requests %v", paths)
+	}
+	if len(activities) != 1 || activities[0].Title != "Found by search" {
+		t.Errorf("Expected the activity found by search, got %+v", activities)
+	}
+}
+
+func TestEventsSourceUsesCompleteFeed(t *testing.T) {
+	endDate := time.Now()
+	startDate := endDate.Add(-24 * time.Hour)
+	feed := fmt.Sprintf(`[
+		{"type":"IssuesEvent","repo":{"name":"octo/hello"},"payload":{"action":"opened","issue":{"number":4,"title":"Crash"}},"created_at":"%s"},
+		{"type":"IssuesEvent","repo":{"name":"octo/hello"},"payload":{"action":"opened","issue":{"number":3,"title":"Old"}},"created_at":"%s"}
+	]`, endDate.Add(-time.Hour).UTC().Format(time.RFC3339), startDate.Add(-time.Hour).UTC().Format(time.RFC3339))
+	client, requested := newEventsTestClient(t, func(string) string {
+		return feed
+	}, endDate)
+
+	source := &eventsSource{client: client}
+	activities, err := source.Collect(context.Background(), Query{Username: "octocat", StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if paths := requested(); countPaths(paths, "/search/commits") != 0 {
+		t.Errorf("Expected no fallback to search, got requests %v", paths)
+	}
+	if len(activities) != 1 || activities[0].Number != 4 {
+		t.Errorf("Expected only the issue opened within the window, got %+v", activities)
+	}
+}
@@ -0,0 +1,339 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// contributionsQuery fetches commits, pull requests, issues and reviews for the
+// window. GitHub limits the window to one year. The first request fetches
+// every connection; later requests fetch only the next page of the pull
+// request, issue and review connections that have one.
+const contributionsQuery = `
+query StandupContributions(
+  $login: String!, $from: DateTime!, $to: DateTime!,
+  $withCommits: Boolean!, $withPullRequests: Boolean!, $withIssues: Boolean!, $withReviews: Boolean!,
+  $pullRequestsAfter: String, $issuesAfter: String, $reviewsAfter: String
+) {
+  user(login: $login) {
+    contributionsCollection(from: $from, to: $to) {
+      commitContributionsByRepository(maxRepositories: 100) @include(if: $withCommits) {
+        repository { nameWithOwner }
+        contributions(first: 100) {
+          totalCount
+          nodes { occurredAt commitCount url }
+        }
+      }
+      pullRequestContributions(first: 100, after: $pullRequestsAfter) @include(if: $withPullRequests) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          occurredAt
+          pullRequest { number title body url state isDraft additions deletions repository { nameWithOwner } }
+        }
+      }
+      issueContributions(first: 100, after: $issuesAfter) @include(if: $withIssues) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          occurredAt
+          issue { number title body url state repository { nameWithOwner } }
+        }
+      }
+      pullRequestReviewContributions(first: 100, after: $reviewsAfter) @include(if: $withReviews) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          occurredAt
+          pullRequestReview { state submittedAt url comments { totalCount } }
+          pullRequest { number title url repository { nameWithOwner } }
+        }
+      }
+    }
+  }
+}`
+
+// contributionsMaxPages bounds the pages fetched per connection, like the
+// search backend's 1000 results.
+const contributionsMaxPages = 10
+
+type repositoryRef struct {
+	NameWithOwner string `json:"nameWithOwner"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type pullRequestContributions struct {
+	TotalCount int      `json:"totalCount"`
+	PageInfo   pageInfo `json:"pageInfo"`
+	Nodes      []struct {
+		OccurredAt  time.Time `json:"occurredAt"`
+		PullRequest struct {
+			Number     int           `json:"number"`
+			Title      string        `json:"title"`
+			Body       string        `json:"body"`
+			URL        string        `json:"url"`
+			State      string        `json:"state"`
+			IsDraft    bool          `json:"isDraft"`
+			Additions  int           `json:"additions"`
+			Deletions  int           `json:"deletions"`
+			Repository repositoryRef `json:"repository"`
+		} `json:"pullRequest"`
+	} `json:"nodes"`
+}
+
+type issueContributions struct {
+	TotalCount int      `json:"totalCount"`
+	PageInfo   pageInfo `json:"pageInfo"`
+	Nodes      []struct {
+		OccurredAt time.Time `json:"occurredAt"`
+		Issue      struct {
+			Number     int           `json:"number"`
+			Title      string        `json:"title"`
+			Body       string        `json:"body"`
+			URL        string        `json:"url"`
+			State      string        `json:"state"`
+			Repository repositoryRef `json:"repository"`
+		} `json:"issue"`
+	} `json:"nodes"`
+}
+
+type reviewContributions struct {
+	TotalCount int      `json:"totalCount"`
+	PageInfo   pageInfo `json:"pageInfo"`
+	Nodes      []struct {
+		OccurredAt        time.Time `json:"occurredAt"`
+		PullRequestReview struct {
+			State       string    `json:"state"`
+			SubmittedAt time.Time `json:"submittedAt"`
+			URL         string    `json:"url"`
+			Comments    struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"comments"`
+		} `json:"pullRequestReview"`
+		PullRequest struct {
+			Number     int           `json:"number"`
+			Title      string        `json:"title"`
+			URL        string        `json:"url"`
+			Repository repositoryRef `json:"repository"`
+		} `json:"pullRequest"`
+	} `json:"nodes"`
+}
+
+type contributionsResponse struct {
+	User *struct {
+		ContributionsCollection struct {
+			CommitContributionsByRepository []struct {
+				Repository    repositoryRef `json:"repository"`
+				Contributions struct {
+					TotalCount int `json:"totalCount"`
+					Nodes      []struct {
+						OccurredAt  time.Time `json:"occurredAt"`
+						CommitCount int       `json:"commitCount"`
+						URL         string    `json:"url"`
+					} `json:"nodes"`
+				} `json:"contributions"`
+			} `json:"commitContributionsByRepository"`
+			PullRequestContributions       pullRequestContributions `json:"pullRequestContributions"`
+			IssueContributions             issueContributions       `json:"issueContributions"`
+			PullRequestReviewContributions reviewContributions      `json:"pullRequestReviewContributions"`
+		} `json:"contributionsCollection"`
+	} `json:"user"`
+}
+
+// contributionsSource collects all activity kinds from the GraphQL contributionsCollection.
+type contributionsSource struct {
+	client *Client
+}
+
+func (s *contributionsSource) Name() string { return "contributions" }
+
+func (s *contributionsSource) Collect(ctx context.Context, query Query) ([]types.GitHubActivity, error) {
+	if s.client.graphql == nil {
+		return nil, fmt.Errorf("GraphQL client is not available")
+	}
+	if query.EndDate.Sub(query.StartDate) > 365*24*time.Hour {
+		return nil, fmt.Errorf("the GraphQL backend cannot look back more than one year")
+	}
+
+	variables := map[string]interface{}{
+		"login":            query.Username,
+		"from":             query.StartDate.UTC().Format(time.RFC3339),
+		"to":               query.EndDate.UTC().Format(time.RFC3339),
+		"withCommits":      true,
+		"withPullRequests": true,
+		"withIssues":       true,
+		"withReviews":      true,
+	}
+
+	var response contributionsResponse
+	if err := s.client.graphql.DoWithContext(ctx, contributionsQuery, variables, &response); err != nil {
+		return nil, fmt.Errorf("contributions query failed: %w", err)
+	}
+	if response.User == nil {
+		return nil, fmt.Errorf("user %q not found", query.Username)
+	}
+
+	// Fetch the remaining pages of the connections that have more
+	collection := &response.User.ContributionsCollection
+	variables["withCommits"] = false
+	for page := 1; page < contributionsMaxPages; page++ {
+		prs, issues, reviews := collection.PullRequestContributions.PageInfo, collection.IssueContributions.PageInfo, collection.PullRequestReviewContributions.PageInfo
+		if !prs.HasNextPage && !issues.HasNextPage && !reviews.HasNextPage {
+			break
+		}
+		variables["withPullRequests"], variables["pullRequestsAfter"] = prs.HasNextPage, prs.EndCursor
+		variables["withIssues"], variables["issuesAfter"] = issues.HasNextPage, issues.EndCursor
+		variables["withReviews"], variables["reviewsAfter"] = reviews.HasNextPage, reviews.EndCursor
+
+		var next contributionsResponse
+		if err := s.client.graphql.DoWithContext(ctx, contributionsQuery, variables, &next); err != nil {
+			return nil, fmt.Errorf("contributions query failed: %w", err)
+		}
+		if next.User == nil {
+			return nil, fmt.Errorf("user %q not found", query.Username)
+		}
+		nextCollection := next.User.ContributionsCollection
+
+		if prs.HasNextPage {
+			collection.PullRequestContributions.Nodes = append(collection.PullRequestContributions.Nodes, nextCollection.PullRequestContributions.Nodes...)
+			collection.PullRequestContributions.PageInfo = nextCollection.PullRequestContributions.PageInfo
+		}
+		if issues.HasNextPage {
+			collection.IssueContributions.Nodes = append(collection.IssueContributions.Nodes, nextCollection.IssueContributions.Nodes...)
+			collection.IssueContributions.PageInfo = nextCollection.IssueContributions.PageInfo
+		}
+		if reviews.HasNextPage {
+			collection.PullRequestReviewContributions.Nodes = append(collection.PullRequestReviewContributions.Nodes, nextCollection.PullRequestReviewContributions.Nodes...)
+			collection.PullRequestReviewContributions.PageInfo = nextCollection.PullRequestReviewContributions.PageInfo
+		}
+	}
+
+	return contributionsToActivities(&response, query), nil
+}
+
+// contributionsToActivities converts the GraphQL response, keeping only the
+// contributions within the query's window and repositories.
+func contributionsToActivities(response *contributionsResponse, query Query) []types.GitHubActivity {
+	var activities []types.GitHubActivity
+	matches := func(repository repositoryRef, at time.Time) bool {
+		return inWindow(at, query.StartDate, query.EndDate) && query.Repos.Match(repository.NameWithOwner)
+	}
+	collection := response.User.ContributionsCollection
+
+	for _, byRepo := range collection.CommitContributionsByRepository {
+		if !query.Repos.Match(byRepo.Repository.NameWithOwner) {
+			continue
+		}
+		warnTruncated("commit contributions in "+byRepo.Repository.NameWithOwner, byRepo.Contributions.TotalCount, len(byRepo.Contributions.Nodes))
+		for _, node := range byRepo.Contributions.Nodes {
+			if !dayInWindow(node.OccurredAt, query.StartDate, query.EndDate) {
+				continue
+			}
+			title := fmt.Sprintf("%d commits", node.CommitCount)
+			if node.CommitCount == 1 {
+				title = "1 commit"
+			}
+			activities = append(activities, types.GitHubActivity{
+				Type:        types.KindCommit,
+				Repository:  byRepo.Repository.NameWithOwner,
+				Title:       title,
+				Description: fmt.Sprintf("%s on %s", title, node.OccurredAt.Format("2006-01-02")),
+				URL:         node.URL,
+				// A day overlapping the start of the window is dated at its start
+				CreatedAt: latest(node.OccurredAt, query.StartDate),
+			})
+		}
+	}
+
+	prs := collection.PullRequestContributions
+	warnTruncated("pull request contributions", prs.TotalCount, len(prs.Nodes))
+	for _, node := range prs.Nodes {
+		pr := node.PullRequest
+		if !matches(pr.Repository, node.OccurredAt) {
+			continue
+		}
+		activities = append(activities, types.GitHubActivity{
+			Type:        types.KindPullRequest,
+			Repository:  pr.Repository.NameWithOwner,
+			Title:       fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title),
+			Description: pr.Body,
+			URL:         pr.URL,
+			CreatedAt:   node.OccurredAt,
+			Number:      pr.Number,
+			State:       strings.ToLower(pr.State),
+			Merged:      pr.State == "MERGED",
+			Draft:       pr.IsDraft,
+			Additions:   pr.Additions,
+			Deletions:   pr.Deletions,
+		})
+	}
+
+	issues := collection.IssueContributions
+	warnTruncated("issue contributions", issues.TotalCount, len(issues.Nodes))
+	for _, node := range issues.Nodes {
+		issue := node.Issue
+		if !matches(issue.Repository, node.OccurredAt) {
+			continue
+		}
+		activities = append(activities, types.GitHubActivity{
+			Type:        types.KindIssue,
+			Repository:  issue.Repository.NameWithOwner,
+			Title:       fmt.Sprintf("Issue #%d: %s", issue.Number, issue.Title),
+			Description: issue.Body,
+			URL:         issue.URL,
+			CreatedAt:   node.OccurredAt,
+			Number:      issue.Number,
+			State:       strings.ToLower(issue.State),
+		})
+	}
+
+	reviews := collection.PullRequestReviewContributions
+	warnTruncated("review contributions", reviews.TotalCount, len(reviews.Nodes))
+	for _, node := range reviews.Nodes {
+		pr := node.PullRequest
+		review := node.PullRequestReview
+		submittedAt := review.SubmittedAt
+		if submittedAt.IsZero() {
+			submittedAt = node.OccurredAt
+		}
+		if !matches(pr.Repository, submittedAt) {
+			continue
+		}
+		activities = append(activities, types.GitHubActivity{
+			Type:        types.KindReview,
+			Repository:  pr.Repository.NameWithOwner,
+			Title:       fmt.Sprintf("Reviewed PR #%d: %s", pr.Number, pr.Title),
+			Description: fmt.Sprintf("Reviewed pull request: %s", pr.Title),
+			URL:         review.URL,
+			CreatedAt:   submittedAt,
+			Number:      pr.Number,
+			Review: &types.ReviewDetail{
+				State:    review.State,
+				Comments: review.Comments.TotalCount,
+			},
+		})
+	}
+
+	return activities
+}
+
+func warnTruncated(what string, total, fetched int) {
+	if total > fetched {
+		log.Printf("  ⚠️  Only %d of %d %s were fetched\n", fetched, total, what)
+	}
+}
+
+func init() {
+	RegisterSource("contributions", func(c *Client) ActivitySource {
+		return &contributionsSource{client: c}
+	}, BackendGraphQL)
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const contributionsFixture = `{
+  "user": {
+    "contributionsCollection": {
+      "commitContributionsByRepository": [
+        {
+          "repository": {"nameWithOwner": "test/repo"},
+          "contributions": {
+            "totalCount": 1,
+            "nodes": [{"occurredAt": "2024-05-02T00:00:00Z", "commitCount": 3, "url": "https://github.com/test/repo/commits"}]
+          }
+        },
+        {
+          "repository": {"nameWithOwner": "other/repo"},
+          "contributions": {
+            "totalCount": 1,
+            "nodes": [{"occurredAt": "2024-05-02T00:00:00Z", "commitCount": 1, "url": "https://github.com/other/repo/commits"}]
+          }
+        }
+      ],
+      "pullRequestContributions": {
+        "totalCount": 1,
+        "nodes": [{
+          "occurredAt": "2024-05-02T10:00:00Z",
+          "pullRequest": {"number": 7, "title": "Add feature", "body": "Body", "url": "https://github.com/test/repo/pull/7", "state": "OPEN", "repository": {"nameWithOwner": "test/repo"}}
+        }]
+      },
+      "issueContributions": {"totalCount": 0, "nodes": []},
+      "pullRequestReviewContributions": {
+        "totalCount": 1,
+        "nodes": [{
+          "occurredAt": "2024-05-02T11:00:00Z",
+          "pullRequestReview": {"state": "CHANGES_REQUESTED", "submittedAt": "2024-05-02T12:00:00Z", "url": "https://github.com/test/repo/pull/8#pullrequestreview-1", "comments": {"totalCount": 2}},
+          "pullRequest": {"number": 8, "title": "Fix bug", "url": "https://github.com/test/repo/pull/8", "repository": {"nameWithOwner": "test/repo"}}
+        }]
+      }
+    }
+  }
+}`
+
+// contributionsWindow covers the whole of the fixture's 2024-05-02.
+var contributionsWindow = Query{
+	StartDate: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+}
+
+func TestContributionsToActivities(t *testing.T) {
+	var response contributionsResponse
+	if err := json.Unmarshal([]byte(contributionsFixture), &response); err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	activities := contributionsToActivities(&response, contributionsWindow)
+	if len(activities) != 4 {
+		t.Fatalf("Expected 4 activities, got %d", len(activities))
+	}
+
+	review := activities[3]
+	if review.Type != "review" {
+		t.Fatalf("Expected last activity to be a review, got %q", review.Type)
+	}
+	if review.CreatedAt.Hour() != 12 {
+		t.Errorf("Expected review time to be the submission time, got %v", review.CreatedAt)
+	}
+	if review.Review == nil || review.Review.State != "CHANGES_REQUESTED" || review.Review.Comments != 2 {
+		t.Errorf("Expected review state and comment count, got %+v", review.Review)
+	}
+}
+
+func TestContributionsToActivitiesFiltersRepo(t *testing.T) {
+	var response contributionsResponse
+	if err := json.Unmarshal([]byte(contributionsFixture), &response); err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	query := contributionsWindow
+	query.Repos = RepoFilter{Repos: []string{"Test/Repo"}}
+	for _, activity := range contributionsToActivities(&response, query) {
+		if activity.Repository != "test/repo" {
+			t.Errorf("Expected only test/repo activities, got %q", activity.Repository)
+		}
+	}
+}
+
+func TestContributionsToActivitiesFiltersWindow(t *testing.T) {
+	var response contributionsResponse
+	if err := json.Unmarshal([]byte(contributionsFixture), &response); err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	// Starts after the pull request but before the review
+	startDate := time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC)
+	query := Query{StartDate: startDate, EndDate: startDate.Add(24 * time.Hour)}
+	activities := contributionsToActivities(&response, query)
+
+	var kinds []string
+	for _, activity := range activities {
+		kinds = append(kinds, string(activity.Type))
+		if activity.CreatedAt.Before(startDate) {
+			t.Errorf("Expected %s to be dated within the window, got %v", activity.Type, activity.CreatedAt)
+		}
+	}
+	if len(activities) != 3 || kinds[0] != "commit" || kinds[1] != "commit" || kinds[2] != "review" {
+		t.Errorf("Expected the day's commits and the review, got %v", kinds)
+	}
+
+	// Starts after the day of the commits
+	query = Query{StartDate: time.Date(2024, 5, 3, 1, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)}
+	if activities := contributionsToActivities(&response, query); len(activities) != 0 {
+		t.Errorf("Expected no activity after the fixture's day, got %+v", activities)
+	}
+}
+
+func TestContributionsSourcePaginates(t *testing.T) {
+	var requests []map[string]interface{}
+	client := newTestClientWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		requests = append(requests, request.Variables)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch len(requests) {
+		case 1:
+			w.Write([]byte(`{"data":{"user":{"contributionsCollection":{
+				"commitContributionsByRepository":[],
+				"pullRequestContributions":{"totalCount":2,"pageInfo":{"hasNextPage":true,"endCursor":"pr-1"},"nodes":[
+					{"occurredAt":"2024-05-02T10:00:00Z","pullRequest":{"number":1,"title":"First","state":"OPEN","repository":{"nameWithOwner":"test/repo"}}}]},
+				"issueContributions":{"totalCount":0,"pageInfo":{"hasNextPage":false},"nodes":[]},
+				"pullRequestReviewContributions":{"totalCount":1,"pageInfo":{"hasNextPage":false},"nodes":[
+					{"occurredAt":"2024-05-02T11:00:00Z","pullRequestReview":{"state":"APPROVED","submittedAt":"2024-05-02T11:00:00Z"},"pullRequest":{"number":8,"title":"Fix","repository":{"nameWithOwner":"test/repo"}}}]}
+			}}}}`))
+		default:
+			w.Write([]byte(`{"data":{"user":{"contributionsCollection":{
+				"pullRequestContributions":{"totalCount":2,"pageInfo":{"hasNextPage":false,"endCursor":"pr-2"},"nodes":[
+					{"occurredAt":"2024-05-02T12:00:00Z","pullRequest":{"number":2,"title":"Second","state":"OPEN","repository":{"nameWithOwner":"test/repo"}}}]}
+			}}}}`))
+		}
+	})
+
+	source := &contributionsSource{client: client}
+	query := contributionsWindow
+	query.Username = "octocat"
+	activities, err := source.Collect(context.Background(), query)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("Expected a request for the second page of pull requests, got %d requests", len(requests))
+	}
+	next := requests[1]
+	if next["pullRequestsAfter"] != "pr-1" || next["withPullRequests"] != true || next["withCommits"] != false || next["withIssues"] != false || next["withReviews"] != false {
+		t.Errorf("Expected only the pull requests after the cursor, got %v", next)
+	}
+
+	var numbers []int
+	for _, activity := range activities {
+		numbers = append(numbers, activity.Number)
+	}
+	if len(numbers) != 3 || numbers[0] != 1 || numbers[1] != 2 || numbers[2] != 8 {
+		t.Errorf("Expected both pages of pull requests and the review, got %v", numbers)
+	}
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// timelineActions maps issue timeline event names to lifecycle actions.
+var timelineActions = map[string]types.PullRequestAction{
+	"merged":                types.ActionMerged,
+	"closed":                types.ActionClosed,
+	"reopened":              types.ActionReopened,
+	"ready_for_review":      types.ActionReadyForReview,
+	"convert_to_draft":      types.ActionConvertedToDraft,
+	"head_ref_force_pushed": types.ActionPushed,
+}
+
+type timelineEvent struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	// Committed events carry their time on the committer instead of created_at
+	Committer struct {
+		Date time.Time `json:"date"`
+	} `json:"committer"`
+}
+
+// getPullRequestEvents finds state transitions within the window on pull
+// requests authored by the user, regardless of when they were opened.
+func (c *Client) getPullRequestEvents(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+	var activities []types.GitHubActivity
+
+	// Any transition bumps the pull request's updated_at, so older pull requests cannot contain one
+	baseQuery := fmt.Sprintf("author:%s updated:>=%s type:pr",
+		username, searchTime(startDate))
+
+	baseQuery += repos.qualifiers()
+
+	var searchResult struct {
+		Items []searchIssue `json:"items"`
+	}
+
+	// Pagination to get all updated pull requests
+	page := 1
+	perPage := 100
+
+	for {
+		err := c.get(ctx, fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d&sort=updated&order=desc", url.QueryEscape(baseQuery), perPage, page), &searchResult)
+		if err != nil {
+			return activities, err
+		}
+
+		// If no items returned, we've reached the end
+		if len(searchResult.Items) == 0 {
+			break
+		}
+
+		for _, item := range searchResult.Items {
+			itemRepo := repoFromAPIURL(item.RepositoryURL)
+			if !repos.Match(itemRepo) {
+				continue
+			}
+
+			events, err := c.getTimeline(ctx, itemRepo, item.Number)
+			if err != nil {
+				return activities, fmt.Errorf("failed to get timeline of %s#%d: %w", itemRepo, item.Number, err)
+			}
+
+			for _, transition := range pullRequestTransitions(events, startDate, endDate) {
+				activity := item.activity(types.KindPullRequestEvent)
+				activity.Title = fmt.Sprintf("PR #%d: %s", item.Number, item.Title)
+				activity.Description = transition.description
+				activity.CreatedAt = transition.at
+				activity.PullRequestEvent = &types.PullRequestEventDetail{
+					Action:  transition.action,
+					Commits: transition.commits,
+				}
+				activities = append(activities, activity)
+			}
+		}
+
+		// If we got less than perPage items, we've reached the end
+		if len(searchResult.Items) < perPage {
+			break
+		}
+
+		// Move to next page
+		page++
+
+		// Safety check to prevent infinite loops
+		if page > 10 { // Max 1000 pull requests (100 * 10 pages)
+			break
+		}
+	}
+
+	return activities, nil
+}
+
+func (c *Client) getTimeline(ctx context.Context, repo string, number int) ([]timelineEvent, error) {
+	var events []timelineEvent
+
+	page := 1
+	perPage := 100
+
+	for {
+		var pageEvents []timelineEvent
+		err := c.get(ctx, fmt.Sprintf("repos/%s/issues/%d/timeline?per_page=%d&page=%d", repo, number, perPage, page), &pageEvents)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, pageEvents...)
+
+		if len(pageEvents) < perPage || page >= 10 {
+			break
+		}
+		page++
+	}
+
+	return events, nil
+}
+
+type pullRequestTransition struct {
+	action      types.PullRequestAction
+	description string
+	at          time.Time
+	commits     int
+}
+
+// pullRequestTransitions reduces a timeline to the transitions within the
+// window. Commits pushed within the window are folded into a single entry.
+func pullRequestTransitions(events []timelineEvent, startDate, endDate time.Time) []pullRequestTransition {
+	var transitions []pullRequestTransition
+	var mergedAt []time.Time
+	pushedCommits := 0
+	var lastPush time.Time
+
+	for _, event := range events {
+		if event.Event == "committed" {
+			if inWindow(event.Committer.Date, startDate, endDate) {
+				pushedCommits++
+				if event.Committer.Date.After(lastPush) {
+					lastPush = event.Committer.Date
+				}
+			}
+			continue
+		}
+
+		action, ok := timelineActions[event.Event]
+		if !ok || !inWindow(event.CreatedAt, startDate, endDate) {
+			continue
+		}
+		if action == types.ActionPushed {
+			if event.CreatedAt.After(lastPush) {
+				lastPush = event.CreatedAt
+			}
+			continue
+		}
+		if action == types.ActionMerged {
+			mergedAt = append(mergedAt, event.CreatedAt)
+		}
+		transitions = append(transitions, pullRequestTransition{
+			action:      action,
+			description: describeTransition(action),
+			at:          event.CreatedAt,
+		})
+	}
+
+	// Merging also closes the pull request at the same time, which is not
+	// worth reporting twice; earlier closes are kept
+	if len(mergedAt) > 0 {
+		filtered := transitions[:0]
+		for _, transition := range transitions {
+			if transition.action != types.ActionClosed || !slices.ContainsFunc(mergedAt, transition.at.Equal) {
+				filtered = append(filtered, transition)
+			}
+		}
+		transitions = filtered
+	}
+
+	if !lastPush.IsZero() {
+		description := "Pushed new changes"
+		switch {
+		case pushedCommits == 1:
+			description = "Pushed 1 commit"
+		case pushedCommits > 1:
+			description = fmt.Sprintf("Pushed %d commits", pushedCommits)
+		}
+		transitions = append(transitions, pullRequestTransition{
+			action:      types.ActionPushed,
+			description: description,
+			at:          lastPush,
+			commits:     pushedCommits,
+		})
+	}
+
+	return transitions
+}
+
+func describeTransition(action types.PullRequestAction) string {
+	switch action {
+	case types.ActionMerged:
+		return "Merged"
+	case types.ActionClosed:
+		return "Closed without merging"
+	case types.ActionReopened:
+		return "Reopened"
+	case types.ActionReadyForReview:
+		return "Marked ready for review"
+	case types.ActionConvertedToDraft:
+		return "Converted to draft"
+	default:
+		return string(action)
+	}
+}
+
+func init() {
+	registerSearchSource("pull_request_events", false, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getPullRequestEvents(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+}
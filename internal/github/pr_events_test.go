@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+func TestPullRequestTransitions(t *testing.T) {
+	start := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	event := func(name string, at time.Time) timelineEvent {
+		return timelineEvent{Event: name, CreatedAt: at}
+	}
+	commit := func(at time.Time) timelineEvent {
+		e := timelineEvent{Event: "committed"}
+		e.Committer.Date = at
+		return e
+	}
+
+	events := []timelineEvent{
+		commit(start.Add(-time.Hour)), // before the window
+		commit(start.Add(time.Hour)),
+		commit(start.Add(2 * time.Hour)),
+		event("ready_for_review", start.Add(3*time.Hour)),
+		event("labeled", start.Add(4*time.Hour)),
+		event("merged", start.Add(5*time.Hour)),
+		event("closed", start.Add(5*time.Hour)),
+	}
+
+	transitions := pullRequestTransitions(events, start, end)
+
+	actions := make([]types.PullRequestAction, len(transitions))
+	for i, transition := range transitions {
+		actions[i] = transition.action
+	}
+
+	expected := []types.PullRequestAction{types.ActionReadyForReview, types.ActionMerged, types.ActionPushed}
+	if len(actions) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, actions)
+	}
+	for i := range expected {
+		if actions[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, actions)
+		}
+	}
+
+	if pushed := transitions[2]; pushed.description != "Pushed 2 commits" {
+		t.Errorf("Expected pushed commits to be folded together, got %q", pushed.description)
+	}
+}
+
+func TestPullRequestTransitionsKeepsEarlierClose(t *testing.T) {
+	start := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	events := []timelineEvent{
+		{Event: "closed", CreatedAt: start.Add(time.Hour)},
+		{Event: "reopened", CreatedAt: start.Add(2 * time.Hour)},
+		{Event: "merged", CreatedAt: start.Add(3 * time.Hour)},
+		{Event: "closed", CreatedAt: start.Add(3 * time.Hour)},
+	}
+
+	var actions []types.PullRequestAction
+	for _, transition := range pullRequestTransitions(events, start, end) {
+		actions = append(actions, transition.action)
+	}
+
+	expected := []types.PullRequestAction{types.ActionClosed, types.ActionReopened, types.ActionMerged}
+	if len(actions) != len(expected) || actions[0] != expected[0] || actions[1] != expected[1] || actions[2] != expected[2] {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+}
+
+func TestGetPullRequestEvents(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/search/issues": `{"items":[
+			{"number":1,"title":"Merged","state":"closed","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/pull/1","pull_request":{"merged_at":"2024-05-02T10:00:00Z"}},
+			{"number":2,"title":"Reopened","state":"open","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/pull/2","pull_request":{}},
+			{"number":3,"title":"Pushed","state":"open","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/pull/3","pull_request":{}}
+		]}`,
+		"/repos/octo/hello/issues/1/timeline": `[
+			{"event":"merged","created_at":"2024-05-02T10:00:00Z"},
+			{"event":"closed","created_at":"2024-05-02T10:00:00Z"}
+		]`,
+		"/repos/octo/hello/issues/2/timeline": `[
+			{"event":"closed","created_at":"2024-05-01T09:00:00Z"},
+			{"event":"closed","created_at":"2024-05-02T09:00:00Z"},
+			{"event":"reopened","created_at":"2024-05-02T11:00:00Z"}
+		]`,
+		"/repos/octo/hello/issues/3/timeline": `[
+			{"event":"committed","committer":{"date":"2024-05-02T08:00:00Z"}},
+			{"event":"committed","committer":{"date":"2024-05-02T12:00:00Z"}},
+			{"event":"labeled","created_at":"2024-05-02T13:00:00Z"}
+		]`,
+	})
+
+	start := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	activities, err := client.getPullRequestEvents(context.Background(), "octocat", RepoFilter{}, start, end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	type transition struct {
+		number  int
+		action  types.PullRequestAction
+		commits int
+	}
+	expected := []transition{
+		{1, types.ActionMerged, 0},
+		{2, types.ActionClosed, 0},
+		{2, types.ActionReopened, 0},
+		{3, types.ActionPushed, 2},
+	}
+	if len(activities) != len(expected) {
+		t.Fatalf("Expected %d transitions, got %d: %+v", len(expected), len(activities), activities)
+	}
+	for i, activity := range activities {
+		if activity.Type != types.KindPullRequestEvent || activity.PullRequestEvent == nil {
+			t.Fatalf("Unexpected activity: %+v", activity)
+		}
+		got := transition{activity.Number, activity.PullRequestEvent.Action, activity.PullRequestEvent.Commits}
+		if got != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], got)
+		}
+	}
+	if !activities[0].Merged || activities[0].State != "merged" {
+		t.Errorf("Expected the merged pull request to be marked merged, got %+v", activities[0])
+	}
+	if !activities[3].CreatedAt.Equal(time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the push to be dated at the last commit, got %v", activities[3].CreatedAt)
+	}
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// RepoFilter selects the repositories activity is collected from. The zero
+// value selects every repository.
+type RepoFilter struct {
+	// Repos are owner/repo names or glob patterns such as acme/*-service.
+	Repos []string
+	// Orgs select every repository owned by an organization or user.
+	Orgs []string
+	// Exclude are owner/repo names or glob patterns left out even when
+	// selected by Repos or Orgs.
+	Exclude []string
+}
+
+// NewRepoFilter checks repository names and patterns and builds a filter.
+func NewRepoFilter(repos, orgs, exclude []string) (RepoFilter, error) {
+	for _, pattern := range append(append([]string{}, repos...), exclude...) {
+		owner, name, ok := strings.Cut(pattern, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return RepoFilter{}, fmt.Errorf("invalid repository %q (expected owner/repo, optionally with * wildcards)", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return RepoFilter{}, fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+		}
+	}
+	for _, org := range orgs {
+		if org == "" || strings.Contains(org, "/") {
+			return RepoFilter{}, fmt.Errorf("invalid organization %q", org)
+		}
+	}
+
+	return RepoFilter{Repos: repos, Orgs: orgs, Exclude: exclude}, nil
+}
+
+// Match reports whether the filter selects the repository named owner/repo.
+// Matching is case-insensitive.
+func (f RepoFilter) Match(fullName string) bool {
+	fullName = strings.ToLower(fullName)
+	for _, pattern := range f.Exclude {
+		if matchRepo(pattern, fullName) {
+			return false
+		}
+	}
+	if len(f.Repos) == 0 && len(f.Orgs) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.Repos {
+		if matchRepo(pattern, fullName) {
+			return true
+		}
+	}
+	owner, _, _ := strings.Cut(fullName, "/")
+	for _, org := range f.Orgs {
+		if strings.EqualFold(org, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// Search rejects queries longer than 256 characters or with more than five
+// AND, OR or NOT operators. Qualifiers for the filter are kept well within
+// these limits, leaving room for the rest of the query.
+const (
+	maxSearchQualifiers       = 5
+	maxSearchQualifiersLength = 150
+)
+
+// qualifiers returns search qualifiers narrowing results to the filter, with
+// a leading space. Search ORs repo:, user: and org: qualifiers. Patterns
+// cannot be searched for: their owner is searched instead, or every
+// repository when the owner is a pattern too. Repositories and organizations
+// beyond the search limits are not searched for either, and exclusions beyond
+// them are dropped, so results must still be checked with Match.
+func (f RepoFilter) qualifiers() string {
+	var scopes []string
+	unscoped := false
+	for _, pattern := range f.Repos {
+		owner, _, _ := strings.Cut(pattern, "/")
+		switch {
+		case !isPattern(pattern):
+			scopes = append(scopes, "repo:"+pattern)
+		case !isPattern(owner):
+			scopes = append(scopes, "user:"+owner)
+		default:
+			unscoped = true
+		}
+	}
+	for _, org := range f.Orgs {
+		scopes = append(scopes, "org:"+org)
+	}
+	scopes = uniqueQualifiers(scopes)
+
+	query := ""
+	if !unscoped && len(scopes) > 0 && len(scopes) <= maxSearchQualifiers {
+		query = " " + strings.Join(scopes, " ")
+	}
+	if len(query) > maxSearchQualifiersLength {
+		query = ""
+	}
+	count := strings.Count(query, " ")
+
+	var excludes []string
+	for _, pattern := range f.Exclude {
+		if !isPattern(pattern) {
+			excludes = append(excludes, "-repo:"+pattern)
+		}
+	}
+	for _, exclude := range uniqueQualifiers(excludes) {
+		if count >= maxSearchQualifiers || len(query)+1+len(exclude) > maxSearchQualifiersLength {
+			break
+		}
+		query += " " + exclude
+		count++
+	}
+	return query
+}
+
+// uniqueQualifiers returns qualifiers without case-insensitive duplicates.
+func uniqueQualifiers(qualifiers []string) []string {
+	var unique []string
+	seen := map[string]bool{}
+	for _, qualifier := range qualifiers {
+		if !seen[strings.ToLower(qualifier)] {
+			seen[strings.ToLower(qualifier)] = true
+			unique = append(unique, qualifier)
+		}
+	}
+	return unique
+}
+
+// matchRepo reports whether the lower-case fullName matches pattern.
+func matchRepo(pattern, fullName string) bool {
+	matched, _ := path.Match(strings.ToLower(pattern), fullName)
+	return matched
+}
+
+func isPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
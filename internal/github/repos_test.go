@@ -0,0 +1,78 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepoFilterMatch(t *testing.T) {
+	filter, err := NewRepoFilter([]string{"acme/api", "acme/*-service"}, []string{"Tools"}, []string{"acme/legacy-service", "tools/*-archive"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]bool{
+		"acme/api":             true,
+		"ACME/API":             true,
+		"acme/billing-service": true,
+		"acme/legacy-service":  false,
+		"acme/web":             false,
+		"tools/linter":         true,
+		"tools/old-archive":    false,
+		"octocat/side-project": false,
+	}
+	for repo, want := range tests {
+		if got := filter.Match(repo); got != want {
+			t.Errorf("Match(%q) = %v, want %v", repo, got, want)
+		}
+	}
+
+	if !(RepoFilter{}).Match("octocat/side-project") {
+		t.Error("Expected an empty filter to match every repository")
+	}
+	if (RepoFilter{Exclude: []string{"octocat/*"}}).Match("octocat/side-project") {
+		t.Error("Expected exclusions to apply without other filters")
+	}
+}
+
+func TestRepoFilterQualifiers(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter RepoFilter
+		want   string
+	}{
+		{"none", RepoFilter{}, ""},
+		{"repos and orgs", RepoFilter{Repos: []string{"acme/api", "acme/web"}, Orgs: []string{"tools"}}, " repo:acme/api repo:acme/web org:tools"},
+		{"pattern searches its owner", RepoFilter{Repos: []string{"acme/*-service", "acme/*-worker"}}, " user:acme"},
+		{"owner pattern searches everything", RepoFilter{Repos: []string{"acme/api", "*/api"}, Exclude: []string{"acme/old", "acme/*-archive"}}, " -repo:acme/old"},
+		{"too many repos searches everything", RepoFilter{Repos: []string{"acme/a", "acme/b", "acme/c", "acme/d", "acme/e", "acme/f"}, Exclude: []string{"acme/old"}}, " -repo:acme/old"},
+		{"too long searches everything", RepoFilter{Repos: []string{"acme/" + strings.Repeat("a", 80), "acme/" + strings.Repeat("b", 80)}}, ""},
+		{"exclusions within the limit", RepoFilter{Orgs: []string{"acme", "tools"}, Exclude: []string{"acme/a", "acme/b", "acme/c", "acme/d"}}, " org:acme org:tools -repo:acme/a -repo:acme/b -repo:acme/c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.qualifiers(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewRepoFilterErrors(t *testing.T) {
+	tests := []struct {
+		repos, orgs, exclude []string
+	}{
+		{repos: []string{"api"}},
+		{repos: []string{"acme/api/extra"}},
+		{repos: []string{"acme/[api"}},
+		{exclude: []string{"/api"}},
+		{orgs: []string{"acme/api"}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewRepoFilter(tt.repos, tt.orgs, tt.exclude); err == nil {
+			t.Errorf("Expected error for %+v", tt)
+		}
+	}
+}
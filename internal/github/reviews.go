@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+type pullRequestReview struct {
+	ID   int64 `json:"id"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"`
+	HTMLURL     string    `json:"html_url"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// getReviews finds reviews submitted by the user within the window.
+//
+// The search API can only tell which pull requests the user reviewed at some
+// point, so it is used to find candidates updated since the start of the
+// window, and the reviews of each candidate are then filtered by submission time.
+func (c *Client) getReviews(ctx context.Context, username string, repos RepoFilter, startDate, endDate time.Time) ([]types.GitHubActivity, error) {
+	var activities []types.GitHubActivity
+
+	// A review bumps the pull request's updated_at, so older pull requests cannot contain one
+	baseQuery := fmt.Sprintf("reviewed-by:%s updated:>=%s type:pr",
+		username, searchTime(startDate))
+	baseQuery += repos.qualifiers()
+
+	var searchResult struct {
+		Items []searchIssue `json:"items"`
+	}
+
+	// Pagination to get all reviewed pull requests
+	page := 1
+	perPage := 100
+
+	for {
+		err := c.get(ctx, fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d&sort=updated&order=desc", url.QueryEscape(baseQuery), perPage, page), &searchResult)
+		if err != nil {
+			return activities, err
+		}
+
+		// If no items returned, we've reached the end
+		if len(searchResult.Items) == 0 {
+			break
+		}
+
+		for _, item := range searchResult.Items {
+			repo := repoFromAPIURL(item.RepositoryURL)
+			if !repos.Match(repo) {
+				continue
+			}
+
+			reviews, err := c.getUserReviews(ctx, repo, item.Number, username, startDate, endDate)
+			if err != nil {
+				return activities, fmt.Errorf("failed to list reviews of %s#%d: %w", repo, item.Number, err)
+			}
+			if len(reviews) == 0 {
+				continue
+			}
+
+			commentCounts, err := c.getReviewCommentCounts(ctx, repo, item.Number)
+			if err != nil {
+				return activities, fmt.Errorf("failed to list review comments of %s#%d: %w", repo, item.Number, err)
+			}
+
+			for _, review := range reviews {
+				activity := item.activity(types.KindReview)
+				activity.Title = fmt.Sprintf("Reviewed PR #%d: %s", item.Number, item.Title)
+				activity.Description = fmt.Sprintf("Reviewed pull request: %s", item.Title)
+				activity.URL = review.HTMLURL
+				activity.CreatedAt = review.SubmittedAt
+				activity.Review = &types.ReviewDetail{
+					State:    review.State,
+					Comments: commentCounts[review.ID],
+				}
+				activities = append(activities, activity)
+			}
+		}
+
+		// If we got less than perPage items, we've reached the end
+		if len(searchResult.Items) < perPage {
+			break
+		}
+
+		// Move to next page
+		page++
+
+		// Safety check to prevent infinite loops
+		if page > 10 { // Max 1000 pull requests (100 * 10 pages)
+			break
+		}
+	}
+
+	return activities, nil
+}
+
+// getUserReviews returns the reviews the user submitted on a pull request within the window.
+func (c *Client) getUserReviews(ctx context.Context, repo string, number int, username string, startDate, endDate time.Time) ([]pullRequestReview, error) {
+	var matching []pullRequestReview
+
+	page := 1
+	perPage := 100
+
+	for {
+		var reviews []pullRequestReview
+		err := c.get(ctx, fmt.Sprintf("repos/%s/pulls/%d/reviews?per_page=%d&page=%d", repo, number, perPage, page), &reviews)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, review := range reviews {
+			// Pending reviews have not been submitted yet
+			if review.State == "PENDING" || !strings.EqualFold(review.User.Login, username) {
+				continue
+			}
+			if !inWindow(review.SubmittedAt, startDate, endDate) {
+				continue
+			}
+			matching = append(matching, review)
+		}
+
+		if len(reviews) < perPage || page >= 10 {
+			break
+		}
+		page++
+	}
+
+	return matching, nil
+}
+
+// getReviewCommentCounts returns the number of inline comments per review ID.
+func (c *Client) getReviewCommentCounts(ctx context.Context, repo string, number int) (map[int64]int, error) {
+	counts := make(map[int64]int)
+
+	page := 1
+	perPage := 100
+
+	for {
+		var comments []struct {
+			PullRequestReviewID int64 `json:"pull_request_review_id"`
+		}
+		err := c.get(ctx, fmt.Sprintf("repos/%s/pulls/%d/comments?per_page=%d&page=%d", repo, number, perPage, page), &comments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, comment := range comments {
+			counts[comment.PullRequestReviewID]++
+		}
+
+		if len(comments) < perPage || page >= 10 {
+			break
+		}
+		page++
+	}
+
+	return counts, nil
+}
+
+// repoFromAPIURL extracts "owner/repo" from an API URL such as
+// https://api.github.com/repos/owner/repo.
+func repoFromAPIURL(apiURL string) string {
+	if i := strings.Index(apiURL, "/repos/"); i >= 0 {
+		return apiURL[i+len("/repos/"):]
+	}
+	return apiURL
+}
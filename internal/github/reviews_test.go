@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/gh-standup/internal/types"
+)
+
+// rewriteTransport sends every request to a test server.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestClient returns a client whose REST requests are answered with the
+// JSON in responses, keyed by path. Unknown paths fail the test.
+func newTestClient(t *testing.T, responses map[string]string) *Client {
+	t.Helper()
+	return newTestClientWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+}
+
+// newTestClientWithHandler returns a client whose REST and GraphQL requests
+// are served by handler.
+func newTestClientWithHandler(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	opts := api.ClientOptions{
+		Host:         "github.com",
+		AuthToken:    "test-token",
+		Transport:    rewriteTransport{target: target},
+		LogIgnoreEnv: true,
+	}
+	client, err := api.NewRESTClient(opts)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	graphql, err := api.NewGraphQLClient(opts)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return &Client{client: client, graphql: graphql}
+}
+
+func TestGetReviews(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/search/issues": `{"items":[{"number":7,"title":"Add cache","repository_url":"https://api.github.com/repos/octo/hello","html_url":"https://github.com/octo/hello/pull/7","user":{"login":"hubot"},"pull_request":{}}]}`,
+		"/repos/octo/hello/pulls/7/reviews": `[
+			{"id":1,"user":{"login":"octocat"},"state":"COMMENTED","html_url":"https://github.com/octo/hello/pull/7#pullrequestreview-1","submitted_at":"2024-01-01T09:00:00Z"},
+			{"id":2,"user":{"login":"octocat"},"state":"CHANGES_REQUESTED","html_url":"https://github.com/octo/hello/pull/7#pullrequestreview-2","submitted_at":"2024-01-02T10:00:00Z"},
+			{"id":3,"user":{"login":"hubot"},"state":"COMMENTED","html_url":"https://github.com/octo/hello/pull/7#pullrequestreview-3","submitted_at":"2024-01-02T11:00:00Z"},
+			{"id":4,"user":{"login":"OctoCat"},"state":"APPROVED","html_url":"https://github.com/octo/hello/pull/7#pullrequestreview-4","submitted_at":"2024-01-02T15:00:00Z"},
+			{"id":5,"user":{"login":"octocat"},"state":"PENDING","html_url":"https://github.com/octo/hello/pull/7#pullrequestreview-5"}
+		]`,
+		"/repos/octo/hello/pulls/7/comments": `[
+			{"pull_request_review_id":1},
+			{"pull_request_review_id":2},
+			{"pull_request_review_id":2},
+			{"pull_request_review_id":3}
+		]`,
+	})
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	activities, err := client.getReviews(context.Background(), "octocat", RepoFilter{}, start, end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(activities) != 2 {
+		t.Fatalf("Expected the user's 2 reviews in the window, got %d: %+v", len(activities), activities)
+	}
+
+	changes, approval := activities[0], activities[1]
+	if changes.Type != types.KindReview || changes.Repository != "octo/hello" || changes.Number != 7 {
+		t.Errorf("Unexpected review activity: %+v", changes)
+	}
+	if changes.Review == nil || changes.Review.State != "CHANGES_REQUESTED" || changes.Review.Comments != 2 {
+		t.Errorf("Expected changes requested with 2 inline comments, got %+v", changes.Review)
+	}
+	if !changes.CreatedAt.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the submission time, got %v", changes.CreatedAt)
+	}
+	if changes.URL != "https://github.com/octo/hello/pull/7#pullrequestreview-2" {
+		t.Errorf("Expected a link to the review, got %q", changes.URL)
+	}
+	if approval.Review == nil || approval.Review.State != "APPROVED" || approval.Review.Comments != 0 {
+		t.Errorf("Expected an approval without inline comments, got %+v", approval.Review)
+	}
+}
+
+func TestGetReviewsSkipsPullRequestsWithoutReviewsInWindow(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"/search/issues":                    `{"items":[{"number":7,"title":"Add cache","repository_url":"https://api.github.com/repos/octo/hello","pull_request":{}}]}`,
+		"/repos/octo/hello/pulls/7/reviews": `[{"id":1,"user":{"login":"octocat"},"state":"APPROVED","submitted_at":"2023-12-20T09:00:00Z"}]`,
+	})
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	activities, err := client.getReviews(context.Background(), "octocat", RepoFilter{}, start, end)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 0 {
+		t.Errorf("Expected no reviews, got %+v", activities)
+	}
+}
+
+func TestRepoFromAPIURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com/repos/octo/hello":           "octo/hello",
+		"https://ghe.example.com/api/v3/repos/team/service": "team/service",
+		"octo/hello": "octo/hello",
+	}
+
+	for input, expected := range tests {
+		if got := repoFromAPIURL(input); got != expected {
+			t.Errorf("repoFromAPIURL(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
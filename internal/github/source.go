@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// Query describes the activity window and filters a source collects for.
+type Query struct {
+	Username  string
+	Repos     RepoFilter
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// ActivitySource is a single kind of activity that can be collected for a user.
+type ActivitySource interface {
+	// Name is the identifier used to enable the source via --sources.
+	Name() string
+	// Collect returns the activities matching the query.
+	Collect(ctx context.Context, query Query) ([]types.GitHubActivity, error)
+}
+
+// OptionalSource is implemented by sources whose failures should be reported
+// as warnings instead of aborting the whole collection.
+type OptionalSource interface {
+	Optional() bool
+}
+
+// SourceFactory builds an ActivitySource bound to a GitHub client.
+type SourceFactory func(c *Client) ActivitySource
+
+// Backends decide which sources are enabled when no explicit list is given.
+const (
+	// BackendSearch collects each kind of activity with its own REST search query.
+	BackendSearch = "search"
+	// BackendGraphQL collects everything from the user's contributionsCollection.
+	BackendGraphQL = "graphql"
+	// BackendEvents collects activity from the user's events feed, falling back
+	// to the search backend when the feed cannot cover the window.
+	BackendEvents = "events"
+)
+
+type registeredSource struct {
+	factory    SourceFactory
+	defaultFor []string
+}
+
+var sourceRegistry = map[string]registeredSource{}
+
+// RegisterSource makes a source available by name. The source is enabled by
+// default for each of the listed backends.
+func RegisterSource(name string, factory SourceFactory, defaultFor ...string) {
+	if _, exists := sourceRegistry[name]; exists {
+		panic(fmt.Sprintf("activity source %q registered twice", name))
+	}
+	sourceRegistry[name] = registeredSource{factory: factory, defaultFor: defaultFor}
+}
+
+// SourceNames returns the names of all registered sources, sorted.
+func SourceNames() []string {
+	names := make([]string, 0, len(sourceRegistry))
+	for name := range sourceRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Backends returns the names of all backends that have default sources, sorted.
+func Backends() []string {
+	seen := make(map[string]bool)
+	var backends []string
+	for _, registered := range sourceRegistry {
+		for _, backend := range registered.defaultFor {
+			if !seen[backend] {
+				seen[backend] = true
+				backends = append(backends, backend)
+			}
+		}
+	}
+	sort.Strings(backends)
+	return backends
+}
+
+// DefaultSourceNames returns the names of the sources the backend enables by default, sorted.
+func DefaultSourceNames(backend string) []string {
+	var names []string
+	for _, name := range SourceNames() {
+		for _, b := range sourceRegistry[name].defaultFor {
+			if b == backend {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Sources instantiates the named sources. An empty list selects the defaults
+// of the search backend.
+func (c *Client) Sources(names []string) ([]ActivitySource, error) {
+	if len(names) == 0 {
+		names = DefaultSourceNames(BackendSearch)
+	}
+
+	var sources []ActivitySource
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		registered, ok := sourceRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown activity source %q (available: %s)", name, strings.Join(SourceNames(), ", "))
+		}
+		sources = append(sources, registered.factory(c))
+	}
+
+	return sources, nil
+}
+
+func isOptional(source ActivitySource) bool {
+	optional, ok := source.(OptionalSource)
+	return ok && optional.Optional()
+}
+
+// searchSource adapts one of the Client's search-based collectors to ActivitySource.
+type searchSource struct {
+	name     string
+	optional bool
+	collect  func(ctx context.Context, c *Client, query Query) ([]types.GitHubActivity, error)
+	client   *Client
+}
+
+func (s *searchSource) Name() string { return s.name }
+
+func (s *searchSource) Optional() bool { return s.optional }
+
+func (s *searchSource) Collect(ctx context.Context, query Query) ([]types.GitHubActivity, error) {
+	return s.collect(ctx, s.client, query)
+}
+
+func registerSearchSource(name string, optional bool, collect func(ctx context.Context, c *Client, query Query) ([]types.GitHubActivity, error)) {
+	RegisterSource(name, func(c *Client) ActivitySource {
+		return &searchSource{name: name, optional: optional, collect: collect, client: c}
+	}, BackendSearch)
+}
+
+func init() {
+	registerSearchSource("commits", true, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getCommits(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+	registerSearchSource("pull_requests", false, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getPullRequests(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+	registerSearchSource("issues", false, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getIssues(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+	registerSearchSource("reviews", false, func(ctx context.Context, c *Client, q Query) ([]types.GitHubActivity, error) {
+		return c.getReviews(ctx, q.Username, q.Repos, q.StartDate, q.EndDate)
+	})
+}
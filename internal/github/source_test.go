@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+type fakeSource struct {
+	name       string
+	optional   bool
+	activities []types.GitHubActivity
+	err        error
+	delay      time.Duration
+}
+
+func (s *fakeSource) Name() string   { return s.name }
+func (s *fakeSource) Optional() bool { return s.optional }
+
+func (s *fakeSource) Collect(ctx context.Context, query Query) ([]types.GitHubActivity, error) {
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return s.activities, s.err
+}
+
+func TestDefaultSourceNames(t *testing.T) {
+	expected := []string{"comments", "commits", "issues", "pull_request_events", "pull_requests", "reviews"}
+	names := DefaultSourceNames(BackendSearch)
+
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, names)
+		}
+	}
+}
+
+func TestSourcesUnknownName(t *testing.T) {
+	client := &Client{}
+	if _, err := client.Sources([]string{"commits", "nope"}); err == nil {
+		t.Error("Expected error for unknown source")
+	}
+}
+
+func TestSourcesDeduplicates(t *testing.T) {
+	client := &Client{}
+	sources, err := client.Sources([]string{"issues", " issues", "reviews"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Errorf("Expected 2 sources, got %d", len(sources))
+	}
+}
+
+func TestCollectActivitySkipsOptionalFailures(t *testing.T) {
+	client := &Client{}
+	sources := []ActivitySource{
+		&fakeSource{name: "flaky", optional: true, err: errors.New("boom")},
+		&fakeSource{name: "ok", activities: []types.GitHubActivity{{Type: "issue", Title: "Issue #1: test"}}},
+	}
+
+	activities, err := client.CollectActivity(context.Background(), Query{}, sources, CollectOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 1 {
+		t.Errorf("Expected 1 activity, got %d", len(activities))
+	}
+}
+
+func TestCollectActivityFailsOnRequiredSource(t *testing.T) {
+	client := &Client{}
+	sources := []ActivitySource{
+		&fakeSource{name: "broken", err: errors.New("boom")},
+	}
+
+	if _, err := client.CollectActivity(context.Background(), Query{}, sources, CollectOptions{Concurrency: 2}); err == nil {
+		t.Error("Expected error from required source")
+	}
+}
+
+func TestCollectActivityKeepsSourceOrder(t *testing.T) {
+	client := &Client{}
+	sources := []ActivitySource{
+		&fakeSource{name: "slow", delay: 20 * time.Millisecond, activities: []types.GitHubActivity{{Title: "first"}}},
+		&fakeSource{name: "fast", activities: []types.GitHubActivity{{Title: "second"}}},
+	}
+
+	activities, err := client.CollectActivity(context.Background(), Query{}, sources, CollectOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 2 || activities[0].Title != "first" || activities[1].Title != "second" {
+		t.Errorf("Expected activities in source order, got %v", activities)
+	}
+}
+
+func TestCollectActivitySkipsTimedOutSource(t *testing.T) {
+	client := &Client{}
+	sources := []ActivitySource{
+		&fakeSource{name: "hung", delay: time.Minute},
+		&fakeSource{name: "ok", activities: []types.GitHubActivity{{Title: "done"}}},
+	}
+
+	start := time.Now()
+	activities, err := client.CollectActivity(context.Background(), Query{}, sources, CollectOptions{
+		Concurrency:   2,
+		SourceTimeout: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 1 {
+		t.Errorf("Expected 1 activity, got %d", len(activities))
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected the hung source to be abandoned after its timeout")
+	}
+}
+
+func TestCollectActivityCancelled(t *testing.T) {
+	client := &Client{}
+	sources := []ActivitySource{
+		&fakeSource{name: "hung", optional: true, delay: time.Minute},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.CollectActivity(ctx, Query{}, sources, CollectOptions{Concurrency: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package github
+
+import "time"
+
+// searchTime formats t for search qualifiers such as created:>=. Search
+// accepts ISO 8601 timestamps; UTC keeps "+" offsets out of the query string.
+func searchTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// searchRange formats the window as a search qualifier range, to the second.
+func searchRange(startDate, endDate time.Time) string {
+	return searchTime(startDate) + ".." + searchTime(endDate)
+}
+
+// inWindow reports whether t falls within the window, bounds included.
+// Search results are checked with it, since search may round the window.
+func inWindow(t, startDate, endDate time.Time) bool {
+	return !t.Before(startDate) && !t.After(endDate)
+}
+
+// dayInWindow reports whether the day starting at t overlaps the window.
+// Contributions counted per day are stamped with the start of their day.
+func dayInWindow(t, startDate, endDate time.Time) bool {
+	return t.Add(24*time.Hour).After(startDate) && !t.After(endDate)
+}
+
+// latest returns the later of two times.
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchRange(t *testing.T) {
+	zone := time.FixedZone("PDT", -7*60*60)
+	startDate := time.Date(2025, 6, 10, 17, 0, 0, 0, zone)
+	endDate := time.Date(2025, 6, 11, 9, 30, 15, 0, zone)
+
+	if got, want := searchRange(startDate, endDate), "2025-06-11T00:00:00Z..2025-06-11T16:30:15Z"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	startDate := time.Date(2025, 6, 10, 17, 0, 0, 0, time.UTC)
+	endDate := startDate.Add(16 * time.Hour)
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{startDate.Add(-time.Second), false},
+		{startDate, true},
+		{startDate.Add(time.Hour), true},
+		{endDate, true},
+		{endDate.Add(time.Second), false},
+	}
+	for _, tt := range tests {
+		if got := inWindow(tt.at, startDate, endDate); got != tt.want {
+			t.Errorf("inWindow(%s) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestDayInWindow(t *testing.T) {
+	startDate := time.Date(2025, 6, 10, 17, 0, 0, 0, time.UTC)
+	endDate := startDate.Add(16 * time.Hour)
+
+	tests := []struct {
+		day  time.Time
+		want bool
+	}{
+		{time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := dayInWindow(tt.day, startDate, endDate); got != tt.want {
+			t.Errorf("dayInWindow(%s) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package history keeps the standup reports generated for each user, so
+// that the next report can refer back to the previous one.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxEntries is the number of reports kept for each user.
+const maxEntries = 30
+
+// Entry is a previously generated report and the period it covered.
+type Entry struct {
+	Report    string    `json:"report"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store saves reports as one file per user in a directory.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store in the user's cache directory, e.g.
+// ~/.cache/gh-standup/standups.
+func NewStore() (*Store, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cache directory: %w", err)
+	}
+	return &Store{dir: filepath.Join(dir, "gh-standup", "standups")}, nil
+}
+
+// Previous returns the newest report saved for user whose period ends at or
+// before before. It returns nil when there is none.
+func (s *Store) Previous(user string, before time.Time) (*Entry, error) {
+	entries, err := s.load(user)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *Entry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.EndDate.After(before) {
+			continue
+		}
+		if previous == nil || entry.EndDate.After(previous.EndDate) ||
+			(entry.EndDate.Equal(previous.EndDate) && entry.CreatedAt.After(previous.CreatedAt)) {
+			previous = entry
+		}
+	}
+	return previous, nil
+}
+
+// Save adds entry to the reports saved for user. A report for the same
+// period start, such as one from an earlier run the same day, is replaced.
+func (s *Store) Save(user string, entry Entry) error {
+	entries, err := s.load(user)
+	if err != nil {
+		return err
+	}
+
+	kept := entries[:0]
+	for _, existing := range entries {
+		if !existing.StartDate.Equal(entry.StartDate) {
+			kept = append(kept, existing)
+		}
+	}
+	entries = append(kept, entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save standup: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create standup history directory: %w", err)
+	}
+	if err := os.WriteFile(s.path(user), data, 0o644); err != nil {
+		return fmt.Errorf("failed to save standup: %w", err)
+	}
+	return nil
+}
+
+// load returns the reports saved for user, oldest first.
+func (s *Store) load(user string) ([]Entry, error) {
+	data, err := os.ReadFile(s.path(user))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous standups: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to read previous standups: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *Store) path(user string) string {
+	// Logins cannot contain path separators, but be safe with --user input
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(strings.ToLower(user))
+	return filepath.Join(s.dir, name+".json")
+}
@@ -0,0 +1,65 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	store := &Store{dir: filepath.Join(t.TempDir(), "standups")}
+	monday := time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	entry, err := store.Previous("octocat", tuesday)
+	if err != nil || entry != nil {
+		t.Fatalf("Expected no previous standup, got %+v, %v", entry, err)
+	}
+
+	save := func(user, report string, start, end time.Time) {
+		t.Helper()
+		if err := store.Save(user, Entry{Report: report, StartDate: start, EndDate: end, CreatedAt: end}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	save("octocat", "Started the feature.", monday.AddDate(0, 0, -3), monday)
+	save("octocat", "Shipped the feature.", monday, tuesday)
+	save("hubot", "Reviewed PRs.", monday, tuesday)
+
+	entry, err = store.Previous("OctoCat", tuesday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry == nil || entry.Report != "Shipped the feature." || !entry.StartDate.Equal(monday) || !entry.EndDate.Equal(tuesday) {
+		t.Errorf("Expected the report ending at the period's start, got %+v", entry)
+	}
+
+	// A rerun of Tuesday's standup must not refer to itself
+	entry, err = store.Previous("octocat", monday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry == nil || entry.Report != "Started the feature." {
+		t.Errorf("Expected the report before the period, got %+v", entry)
+	}
+}
+
+func TestStoreReplacesRerun(t *testing.T) {
+	store := &Store{dir: filepath.Join(t.TempDir(), "standups")}
+	start := time.Date(2024, 5, 6, 9, 30, 0, 0, time.UTC)
+
+	for i, report := range []string{"First draft.", "Second draft."} {
+		end := start.AddDate(0, 0, 1).Add(time.Duration(i) * time.Minute)
+		if err := store.Save("octocat", Entry{Report: report, StartDate: start, EndDate: end, CreatedAt: end}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	entries, err := store.load("octocat")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Report != "Second draft." {
+		t.Errorf("Expected the rerun to replace the earlier report, got %+v", entries)
+	}
+}
@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicVersion = "2023-06-01"
+	// anthropicMaxTokens is required by the Messages API and is plenty for a standup.
+	anthropicMaxTokens = 4096
+)
+
+// anthropicProvider talks to the Anthropic Messages API.
+type anthropicProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type anthropicRequest struct {
+	Model         string    `json:"model"`
+	System        string    `json:"system,omitempty"`
+	Messages      []Message `json:"messages"`
+	MaxTokens     int       `json:"max_tokens"`
+	Temperature   *float64  `json:"temperature,omitempty"`
+	TopP          *float64  `json:"top_p,omitempty"`
+	StopSequences []string  `json:"stop_sequences,omitempty"`
+	Stream        bool      `json:"stream,omitempty"`
+}
+
+type anthropicResponse struct {
+	Model   string `json:"model"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string         `json:"stop_reason"`
+	Usage      anthropicUsage `json:"usage"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+func (u anthropicUsage) usage() *Usage {
+	return &Usage{
+		PromptTokens:     u.InputTokens,
+		CompletionTokens: u.OutputTokens,
+		TotalTokens:      u.InputTokens + u.OutputTokens,
+	}
+}
+
+// anthropicFinishReason translates a stop reason to the OpenAI finish reason.
+func anthropicFinishReason(stopReason string) string {
+	switch stopReason {
+	case "max_tokens":
+		return FinishReasonLength
+	case "end_turn", "stop_sequence":
+		return "stop"
+	}
+	return stopReason
+}
+
+func newAnthropicProvider(opts ProviderOptions) (*anthropicProvider, error) {
+	apiKey := firstNonEmpty(opts.APIKey, envOr("ANTHROPIC_API_KEY", ""))
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found for Anthropic. Please set ANTHROPIC_API_KEY")
+	}
+
+	return &anthropicProvider{
+		baseURL:    strings.TrimSuffix(firstNonEmpty(opts.BaseURL, envOr("ANTHROPIC_BASE_URL", "https://api.anthropic.com")), "/"),
+		apiKey:     apiKey,
+		httpClient: newHTTPClient(defaultTimeout, opts.Retry),
+	}, nil
+}
+
+func (p *anthropicProvider) Name() string { return "Anthropic API" }
+
+func (p *anthropicProvider) DefaultModel() string { return "claude-sonnet-4-5" }
+
+func (p *anthropicProvider) Complete(ctx context.Context, request Request) (*Response, error) {
+	var response anthropicResponse
+	if err := postJSON(ctx, p.httpClient, p.baseURL+"/v1/messages", p.headers(), p.buildRequest(request), &response); err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	for _, block := range response.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	return &Response{
+		Model:   response.Model,
+		Choices: []Choice{{Message: Message{Role: "assistant", Content: text.String()}, FinishReason: anthropicFinishReason(response.StopReason)}},
+		Usage:   response.Usage.usage(),
+	}, nil
+}
+
+// anthropicStreamEvent is an event of a streamed message. message_start
+// carries the model and input tokens, message_delta the stop reason and
+// output tokens so far, and error the reason the server aborted the stream.
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Model string         `json:"model"`
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage anthropicUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *anthropicProvider) Stream(ctx context.Context, request Request, onDelta func(string)) (*Response, error) {
+	body := p.buildRequest(request)
+	body.Stream = true
+
+	var content strings.Builder
+	var model, stopReason string
+	var usage anthropicUsage
+	err := postStream(ctx, p.httpClient, p.baseURL+"/v1/messages", p.headers(), body, func(line string) (bool, error) {
+		data, ok := sseData(line)
+		if !ok || data == "" {
+			return false, nil
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return false, fmt.Errorf("failed to unmarshal stream event: %w", err)
+		}
+		switch event.Type {
+		case "message_start":
+			model = event.Message.Model
+			usage = event.Message.Usage
+		case "message_delta":
+			stopReason = event.Delta.StopReason
+			usage.OutputTokens = event.Usage.OutputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" {
+				content.WriteString(event.Delta.Text)
+				onDelta(event.Delta.Text)
+			}
+		case "message_stop":
+			return true, nil
+		case "error":
+			return false, fmt.Errorf("API stream failed with %s: %s", event.Error.Type, event.Error.Message)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Model:   model,
+		Choices: []Choice{{Message: Message{Role: "assistant", Content: content.String()}, FinishReason: anthropicFinishReason(stopReason)}},
+		Usage:   usage.usage(),
+	}, nil
+}
+
+func (p *anthropicProvider) headers() map[string]string {
+	return map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+}
+
+// buildRequest translates an OpenAI-style request to the Messages API, which
+// has no seed, penalties or response format.
+func (p *anthropicProvider) buildRequest(request Request) anthropicRequest {
+	body := anthropicRequest{
+		Model:         request.Model,
+		MaxTokens:     anthropicMaxTokens,
+		StopSequences: request.Stop,
+	}
+	if maxTokens := request.maxTokens(); maxTokens != 0 {
+		body.MaxTokens = maxTokens
+	}
+
+	// The Messages API takes system prompts separately from the conversation
+	var system []string
+	for _, message := range request.Messages {
+		if message.Role == "system" {
+			system = append(system, message.Content)
+			continue
+		}
+		body.Messages = append(body.Messages, message)
+	}
+	body.System = strings.Join(system, "\n\n")
+
+	// Newer models reject requests setting both
+	if request.Temperature != nil {
+		body.Temperature = request.Temperature
+	} else {
+		body.TopP = request.TopP
+	}
+
+	return body
+}
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// Mapping from model name (lowercase) to the number of prompt tokens a single
+// request may use. GitHub Models caps input well below the models' context
+// windows, so its catalog names get much smaller budgets than the same models
+// used directly.
+var modelTokenBudgetMap = map[string]int{
+	"openai/gpt-4o":      8000,
+	"openai/gpt-4o-mini": 8000,
+	"openai/gpt-4.1":     8000,
+	"openai/gpt-5":       4000,
+	"openai/gpt-5-mini":  4000,
+	"xai/grok-3-mini":    4000,
+	"gpt-4o":             120000,
+	"gpt-4o-mini":        120000,
+	"claude-sonnet-4-5":  180000,
+	"llama3.1":           4000, // Ollama's default context, not the model's
+	// Add other models here as needed
+}
+
+// defaultTokenBudget is used for models missing from modelTokenBudgetMap.
+const defaultTokenBudget = 8000
+
+const (
+	// charsPerToken errs on the side of splitting; English prose averages
+	// closer to four characters per token, code and other languages fewer.
+	charsPerToken = 3
+	// messageOverheadTokens covers the role and separators of each message.
+	messageOverheadTokens = 4
+)
+
+// getTokenBudget returns the prompt token budget for the model.
+// Matching is case-insensitive.
+func getTokenBudget(model string) int {
+	if budget, ok := modelTokenBudgetMap[strings.ToLower(model)]; ok {
+		return budget
+	}
+	return defaultTokenBudget
+}
+
+// estimateTokens roughly estimates how many tokens text uses.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// estimateMessagesTokens roughly estimates how many tokens a prompt uses.
+func estimateMessagesTokens(messages []Message) int {
+	total := 0
+	for _, message := range messages {
+		total += estimateTokens(message.Content) + messageOverheadTokens
+	}
+	return total
+}
+
+// chunkActivities splits activities into chunks whose formatted summary fits
+// in budget tokens. Activities of one repository stay together and small
+// repositories share a chunk; a repository too large for a single chunk is
+// split on its own.
+func chunkActivities(activities []types.GitHubActivity, budget int) [][]types.GitHubActivity {
+	var repos []string
+	byRepo := make(map[string][]types.GitHubActivity)
+	for _, activity := range activities {
+		if _, ok := byRepo[activity.Repository]; !ok {
+			repos = append(repos, activity.Repository)
+		}
+		byRepo[activity.Repository] = append(byRepo[activity.Repository], activity)
+	}
+
+	var chunks [][]types.GitHubActivity
+	var current []types.GitHubActivity
+	currentTokens := 0
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, current)
+			current = nil
+			currentTokens = 0
+		}
+	}
+
+	for _, repo := range repos {
+		group := byRepo[repo]
+		tokens := estimateTokens(FormatActivities(group))
+		if tokens <= budget {
+			if currentTokens+tokens > budget {
+				flush()
+			}
+			current = append(current, group...)
+			currentTokens += tokens
+			continue
+		}
+
+		flush()
+		for _, activity := range group {
+			// Counting section headers for every activity overestimates a little
+			tokens := estimateTokens(FormatActivities([]types.GitHubActivity{activity}))
+			if currentTokens+tokens > budget {
+				flush()
+			}
+			current = append(current, activity)
+			currentTokens += tokens
+		}
+		flush()
+	}
+	flush()
+
+	return chunks
+}
+
+// chunkRepositories lists the repositories in a chunk, in order of appearance.
+func chunkRepositories(chunk []types.GitHubActivity) []string {
+	var repos []string
+	seen := make(map[string]bool)
+	for _, activity := range chunk {
+		if !seen[activity.Repository] {
+			seen[activity.Repository] = true
+			repos = append(repos, activity.Repository)
+		}
+	}
+	return repos
+}
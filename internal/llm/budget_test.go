@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gh-standup/internal/types"
+)
+
+func TestGetTokenBudget(t *testing.T) {
+	if got := getTokenBudget("OpenAI/GPT-4o"); got != 8000 {
+		t.Errorf("Expected case-insensitive match, got %d", got)
+	}
+	if got := getTokenBudget("unknown/model"); got != defaultTokenBudget {
+		t.Errorf("Expected default budget, got %d", got)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := estimateTokens(""); got != 0 {
+		t.Errorf("Expected 0 tokens for empty text, got %d", got)
+	}
+	if got := estimateTokens(strings.Repeat("a", 30)); got != 10 {
+		t.Errorf("Expected 10 tokens, got %d", got)
+	}
+}
+
+func TestChunkActivitiesKeepsRepositoriesTogether(t *testing.T) {
+	activities := []types.GitHubActivity{
+		{Type: types.KindCommit, Repository: "a/one", Title: "First"},
+		{Type: types.KindCommit, Repository: "b/two", Title: "Second"},
+		{Type: types.KindCommit, Repository: "a/one", Title: "Third"},
+	}
+
+	chunks := chunkActivities(activities, 1000)
+	if len(chunks) != 1 || len(chunks[0]) != 3 {
+		t.Fatalf("Expected everything in one chunk, got %v", chunks)
+	}
+	if chunks[0][0].Title != "First" || chunks[0][1].Title != "Third" || chunks[0][2].Title != "Second" {
+		t.Errorf("Expected activities grouped by repository, got %v", chunks[0])
+	}
+}
+
+func TestChunkActivitiesSplitsLargeRepositories(t *testing.T) {
+	long := strings.Repeat("x", 150)
+	activities := []types.GitHubActivity{
+		{Type: types.KindCommit, Repository: "a/small", Title: "Tiny"},
+		{Type: types.KindCommit, Repository: "b/large", Title: long + "1"},
+		{Type: types.KindCommit, Repository: "b/large", Title: long + "2"},
+		{Type: types.KindCommit, Repository: "b/large", Title: long + "3"},
+	}
+
+	chunks := chunkActivities(activities, 80)
+	if len(chunks) != 4 {
+		t.Fatalf("Expected 4 chunks, got %d: %v", len(chunks), chunks)
+	}
+	if chunks[0][0].Repository != "a/small" {
+		t.Errorf("Expected the small repository on its own, got %v", chunks[0])
+	}
+	for _, chunk := range chunks[1:] {
+		if len(chunk) != 1 || chunk[0].Repository != "b/large" {
+			t.Errorf("Expected one large activity per chunk, got %v", chunk)
+		}
+	}
+}
+
+func TestGenerateStandupReportSummarizesInChunks(t *testing.T) {
+	modelTokenBudgetMap["tiny-model"] = 400
+	defer delete(modelTokenBudgetMap, "tiny-model")
+
+	provider := &fakeProvider{reply: "Worked on things.", usage: &Usage{PromptTokens: 100, CompletionTokens: 10, TotalTokens: 110}}
+	client := NewClient(provider)
+
+	long := strings.Repeat("x", 600)
+	activities := []types.GitHubActivity{
+		{Type: types.KindCommit, Repository: "a/one", Title: long},
+		{Type: types.KindCommit, Repository: "b/two", Title: long},
+	}
+	prompt := []PromptMessage{{Role: "user", Content: "Report on:\n{{activities}}"}}
+
+	report, err := client.GenerateStandupReport(context.Background(), activities, ReportInfo{}, "tiny-model", prompt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(provider.requests) != 3 {
+		t.Fatalf("Expected two partial summaries and the report, got %d requests", len(provider.requests))
+	}
+	for i, repo := range []string{"a/one", "b/two"} {
+		last := provider.requests[i].Messages[len(provider.requests[i].Messages)-1]
+		if !strings.Contains(last.Content, "- ["+repo+"]") {
+			t.Errorf("Expected part %d to cover %s, got %q", i+1, repo, last.Content)
+		}
+	}
+
+	final := provider.requests[2].Messages[0].Content
+	if strings.Contains(final, long) || !strings.Contains(final, "PART 2 (b/two):\nWorked on things.") {
+		t.Errorf("Expected the report to be built from the partial summaries, got %q", final)
+	}
+	if report.Usage.TotalTokens != 330 {
+		t.Errorf("Expected usage of every call to be added up, got %+v", report.Usage)
+	}
+}
@@ -1,35 +1,54 @@
 package llm
 
 import (
-	"bytes"
+	"context"
 	_ "embed"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"strings"
 	"time"
 
-	"github.com/cli/go-gh/v2/pkg/auth"
 	"github.com/gh-standup/internal/types"
-	"gopkg.in/yaml.v3"
 )
 
 //go:embed standup.prompt.yml
 var standupPromptYAML []byte
 
+// chunkPromptYAML condenses part of the activity when all of it does not fit
+// in one prompt.
+//
+//go:embed chunk.prompt.yml
+var chunkPromptYAML []byte
+
 type PromptConfig struct {
 	Name            string          `yaml:"name"`
 	Description     string          `yaml:"description"`
 	Model           string          `yaml:"model"`
 	ModelParameters ModelParameters `yaml:"modelParameters"`
 	Messages        []PromptMessage `yaml:"messages"`
+	// TestData and Evaluators are used by `gh standup eval` and the GitHub
+	// Models prompt editor. Each test case maps template variables, such as
+	// activities, to values.
+	TestData   []map[string]string `yaml:"testData"`
+	Evaluators []Evaluator         `yaml:"evaluators"`
+
+	// Path is the file the configuration was loaded from; empty for the built-in prompt.
+	Path string `yaml:"-"`
 }
 
+// ModelParameters tune generation. Unset parameters are left to the provider's
+// defaults, and parameters the model does not accept are omitted.
 type ModelParameters struct {
-	Temperature float64 `yaml:"temperature"`
-	TopP        float64 `yaml:"topP"`
+	Temperature      *float64 `yaml:"temperature"`
+	TopP             *float64 `yaml:"topP"`
+	MaxTokens        int      `yaml:"maxTokens"`
+	Seed             *int     `yaml:"seed"`
+	Stop             []string `yaml:"stop"`
+	PresencePenalty  *float64 `yaml:"presencePenalty"`
+	FrequencyPenalty *float64 `yaml:"frequencyPenalty"`
+	// ResponseFormat is "text" or "json_object".
+	ResponseFormat string `yaml:"responseFormat"`
 }
 
 type PromptMessage struct {
@@ -37,12 +56,28 @@ type PromptMessage struct {
 	Content string `yaml:"content"`
 }
 
+// Request is an OpenAI-style chat completion request. Optional parameters are
+// omitted when unset.
 type Request struct {
 	Messages    []Message `json:"messages"`
 	Model       string    `json:"model"`
-	Temperature float64   `json:"temperature"`
-	TopP        float64   `json:"top_p"`
-	Stream      bool      `json:"stream"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	TopP        *float64  `json:"top_p,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	// MaxCompletionTokens replaces MaxTokens for reasoning models.
+	MaxCompletionTokens int             `json:"max_completion_tokens,omitempty"`
+	Seed                *int            `json:"seed,omitempty"`
+	Stop                []string        `json:"stop,omitempty"`
+	PresencePenalty     *float64        `json:"presence_penalty,omitempty"`
+	FrequencyPenalty    *float64        `json:"frequency_penalty,omitempty"`
+	ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+	Stream              bool            `json:"stream"`
+	StreamOptions       *streamOptions  `json:"stream_options,omitempty"`
+}
+
+// ResponseFormat asks for plain text or a JSON object.
+type ResponseFormat struct {
+	Type string `json:"type"`
 }
 
 type Message struct {
@@ -51,77 +86,144 @@ type Message struct {
 }
 
 type Response struct {
-	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
+	// Model is the model that answered, as reported by the provider.
+	Model   string   `json:"model,omitempty"`
+	Choices []Choice `json:"choices"`
+	// Usage is nil when the provider did not report token usage.
+	Usage *Usage `json:"usage,omitempty"`
 }
 
-type Client struct {
-	token string
+// Usage counts the tokens used by model calls.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
 }
 
-// Simple mapping from model name (lowercase) to a safe default temperature
-// to use when the prompt configuration leaves temperature at 0.
-var modelTemperatureMap = map[string]float64{
-	"openai/gpt-5-mini": 1.0,
-	"openai/gpt-5":      1.0,
-	// Add other models here as needed
+// Add adds other to u.
+func (u *Usage) Add(other *Usage) {
+	if other == nil {
+		return
+	}
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+}
 
+// Report is a generated standup report.
+type Report struct {
+	Text string
+	// Model is the model that wrote the report.
+	Model string
+	// Usage adds up every model call made for the report, including the
+	// summaries of condensed activity.
+	Usage Usage
+	// Calls counts those model calls, and Latency is the time spent in them.
+	Calls   int
+	Latency time.Duration
+	// FinishReason is why the model stopped writing the report.
+	FinishReason string
 }
 
-// getMappedTemperature returns a mapped temperature for the model (if any).
-// Matching is case-insensitive.
-func getMappedTemperature(model string) (float64, bool) {
-	if model == "" {
-		return 0, false
-	}
-	v, ok := modelTemperatureMap[strings.ToLower(model)]
-	return v, ok
+// record adds a model call that took latency to the report's statistics.
+func (r *Report) record(response *Response, latency time.Duration) {
+	r.Calls++
+	r.Latency += latency
+	r.Usage.Add(response.Usage)
 }
 
-func NewClient() (*Client, error) {
-	log.Print("  Checking GitHub token... ")
+type Choice struct {
+	Message Message `json:"message"`
+	// FinishReason is why the model stopped writing, such as "stop" or
+	// FinishReasonLength. Empty when the provider did not say.
+	FinishReason string `json:"finish_reason,omitempty"`
+}
 
-	host, _ := auth.DefaultHost()
-	token, _ := auth.TokenForHost(host) // check GH_TOKEN, GITHUB_TOKEN, keychain, etc
+// FinishReasonLength means the model stopped because it reached its output
+// token limit, so the answer is cut off.
+const FinishReasonLength = "length"
 
-	if token == "" {
-		return nil, fmt.Errorf("no GitHub token found. Please run 'gh auth login' to authenticate")
-	}
-	log.Println("Done")
+type Client struct {
+	provider Provider
+	// prompt replaces the built-in prompt configuration, when set.
+	prompt *PromptConfig
+	// stream receives the report as it is generated, when set.
+	stream io.Writer
+	// parameters override the prompt's model parameters, when set.
+	parameters ModelParameters
+	// quiet leaves out progress logs, for clients running concurrently;
+	// warnings are still logged.
+	quiet bool
+}
 
-	return &Client{token: token}, nil
+// NewClient creates a client that generates reports with the given provider.
+func NewClient(provider Provider) *Client {
+	return &Client{provider: provider}
 }
 
-func loadPromptConfig() (*PromptConfig, error) {
-	var config PromptConfig
-	err := yaml.Unmarshal(standupPromptYAML, &config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse prompt configuration: %w", err)
-	}
-	return &config, nil
+// SetStreamWriter makes the client print the report to w as the model
+// generates it. Providers without streaming support write the whole report
+// once it is complete. GenerateStandupReport still returns the full report.
+func (c *Client) SetStreamWriter(w io.Writer) {
+	c.stream = w
+}
+
+// SetPromptConfig makes the client use config instead of the built-in prompt.
+func (c *Client) SetPromptConfig(config *PromptConfig) {
+	c.prompt = config
 }
 
+// SetModelParameters overrides the prompt's model parameters with the ones
+// set in params.
+func (c *Client) SetModelParameters(params ModelParameters) {
+	c.parameters = params
+}
+
+// GenerateStandupReport asks the model for a standup report about activities.
+// info describes the report to prompt templates.
 func (c *Client) GenerateStandupReport(
+	ctx context.Context,
 	activities []types.GitHubActivity,
+	info ReportInfo,
 	model string,
 	promptMessages []PromptMessage,
-) (string, error) {
-	log.Print("  Formatting activity data for AI... ")
+) (*Report, error) {
+	c.progress("  Formatting activity data for AI... ")
 	activitySummary := c.formatActivitiesForLLM(activities)
-	log.Println("Done")
+	c.progress("Done\n")
 
-	log.Print("  Loading prompt configuration... ")
-	promptConfig, err := loadPromptConfig()
-	if err != nil {
-		return "", err
+	return c.generate(ctx, newPromptData(info, activities, activitySummary), model, promptMessages)
+}
+
+// GenerateFromSummary asks the model for a standup report about activity that
+// was already formatted as text, such as the test data of a prompt file.
+func (c *Client) GenerateFromSummary(ctx context.Context, summary string, info ReportInfo, model string) (*Report, error) {
+	return c.generate(ctx, newPromptData(info, nil, summary), model, nil)
+}
+
+// generate renders the prompt with data and sends it to the model.
+func (c *Client) generate(ctx context.Context, data PromptData, model string, promptMessages []PromptMessage) (*Report, error) {
+	promptConfig := c.prompt
+	if promptConfig == nil {
+		c.progress("  Loading prompt configuration... ")
+		var err error
+		promptConfig, err = loadPromptConfig()
+		if err != nil {
+			return nil, err
+		}
+		c.progress("Done\n")
 	}
-	log.Println("Done")
 
-	// Use the model from parameter or fall back to config
+	// Use the model from parameter, then the provider's default, then the config.
+	// The built-in prompt's model names the GitHub Models catalog, which other
+	// providers do not share; a user's prompt file is trusted to name one that fits.
 	selectedModel := model
+	if selectedModel == "" && promptConfig.Path != "" {
+		selectedModel = promptConfig.Model
+	}
+	if selectedModel == "" {
+		selectedModel = c.provider.DefaultModel()
+	}
 	if selectedModel == "" {
 		selectedModel = promptConfig.Model
 	}
@@ -130,50 +232,183 @@ func (c *Client) GenerateStandupReport(
 		promptMessages = promptConfig.Messages
 	}
 
-	// Build messages from the prompt config, replacing template variables
+	messages, err := buildMessages(promptMessages, data)
+	if err != nil {
+		return nil, err
+	}
+
+	params := promptConfig.ModelParameters.merge(c.parameters)
+	request := newRequest(selectedModel, messages, params)
+	report := &Report{Model: selectedModel}
+
+	// Condense the activity first when all of it does not fit in one prompt
+	budget := getTokenBudget(selectedModel)
+	if estimateMessagesTokens(messages) > budget && len(data.Activities) > 0 {
+		condensed, err := c.summarizeInChunks(ctx, data.Activities, data, request, budget, report)
+		if err != nil {
+			return nil, err
+		}
+
+		// The activity list is what did not fit; templates get the condensed notes only
+		data.Summary = condensed
+		data.Activities = nil
+		request.Messages, err = buildMessages(promptMessages, data)
+		if err != nil {
+			return nil, err
+		}
+		if tokens := estimateMessagesTokens(request.Messages); tokens > budget {
+			log.Printf("  ⚠️  Condensed activity still uses about %d of %d tokens\n", tokens, budget)
+		}
+	}
+
+	c.progress("  Calling %s (%s)... ", c.provider.Name(), selectedModel)
+	start := time.Now()
+	response, err := c.complete(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+	c.progress("Done (%s)\n", describeResponse(response, latency))
+
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no response generated from the model")
+	}
+
+	report.Text = strings.TrimSpace(response.Choices[0].Message.Content)
+	report.FinishReason = response.Choices[0].FinishReason
+	if response.Model != "" {
+		report.Model = response.Model
+	}
+	report.record(response, latency)
+	if report.FinishReason == FinishReasonLength {
+		log.Printf("  ⚠️  The report was cut off at the model's output token limit; raise --max-tokens or maxTokens for the rest\n")
+	}
+	return report, nil
+}
+
+// buildMessages builds messages from the prompt config, rendering each
+// message's content as a template with data.
+func buildMessages(promptMessages []PromptMessage, data PromptData) ([]Message, error) {
 	messages := make([]Message, len(promptMessages))
 	for i, msg := range promptMessages {
-		content := msg.Content
-		// Replace the {{activities}} template variable
-		content = strings.ReplaceAll(content, "{{activities}}", activitySummary)
+		content, err := renderPrompt(msg.Content, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render prompt message %d: %w", i+1, err)
+		}
 
 		messages[i] = Message{
 			Role:    msg.Role,
 			Content: content,
 		}
 	}
+	return messages, nil
+}
 
-	// Temperature precedence:
-	// 1. If the model map contains a value for the selected model, use it.
-	// 2. Otherwise use the prompt-configured temperature.
-	effectiveTemperature := promptConfig.ModelParameters.Temperature
-	if mapped, ok := getMappedTemperature(selectedModel); ok {
-		effectiveTemperature = mapped
-	}
-
-	request := Request{
-		Messages:    messages,
-		Model:       selectedModel,
-		Temperature: effectiveTemperature,
-		TopP:        promptConfig.ModelParameters.TopP,
-		Stream:      false,
+// summarizeInChunks condenses activities too large for a single prompt. The
+// activities are split into chunks that fit in the model's budget, each chunk
+// is summarized with the chunk prompt, and the partial summaries are returned
+// in place of the activity list. request supplies the model and parameters,
+// and the model calls are recorded in report.
+func (c *Client) summarizeInChunks(ctx context.Context, activities []types.GitHubActivity, data PromptData, request Request, budget int, report *Report) (string, error) {
+	chunkConfig, err := parsePromptConfig(chunkPromptYAML)
+	if err != nil {
+		return "", err
 	}
 
-	log.Printf("  Calling GitHub Models API (%s)... ", selectedModel)
-	response, err := c.callGitHubModels(request)
+	empty, err := buildMessages(chunkConfig.Messages, newPromptData(data.ReportInfo, nil, ""))
 	if err != nil {
 		return "", err
 	}
-	log.Println("Done")
+	overhead := estimateMessagesTokens(empty)
+	chunks := chunkActivities(activities, budget-overhead)
+	c.progress("  Activity exceeds the %d token budget of %s, summarizing it in %d parts\n", budget, request.Model, len(chunks))
 
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no response generated from the model")
+	var builder strings.Builder
+	builder.WriteString("The activity was too large to include in full. These are condensed notes about it, one part per group of repositories.\n\n")
+	for i, chunk := range chunks {
+		repos := strings.Join(chunkRepositories(chunk), ", ")
+		c.progress("  Summarizing part %d/%d (%s)... ", i+1, len(chunks), repos)
+
+		// Condensed notes are prose even when the report is asked for as JSON
+		chunkRequest := request
+		chunkRequest.ResponseFormat = nil
+		chunkRequest.Messages, err = buildMessages(chunkConfig.Messages, newPromptData(data.ReportInfo, chunk, FormatActivities(chunk)))
+		if err != nil {
+			return "", err
+		}
+		start := time.Now()
+		response, err := c.provider.Complete(ctx, chunkRequest)
+		if err != nil {
+			return "", fmt.Errorf("failed to summarize part %d of the activity: %w", i+1, err)
+		}
+		if len(response.Choices) == 0 {
+			return "", fmt.Errorf("no summary generated for part %d of the activity", i+1)
+		}
+		latency := time.Since(start)
+		c.progress("Done (%s)\n", describeResponse(response, latency))
+		report.record(response, latency)
+		if response.Choices[0].FinishReason == FinishReasonLength {
+			log.Printf("  ⚠️  The summary of part %d was cut off at the model's output token limit\n", i+1)
+		}
+
+		builder.WriteString(fmt.Sprintf("PART %d (%s):\n%s\n\n", i+1, repos, strings.TrimSpace(response.Choices[0].Message.Content)))
+	}
+
+	return builder.String(), nil
+}
+
+// progress logs a step of generating the report, unless the client is quiet.
+func (c *Client) progress(format string, args ...interface{}) {
+	if !c.quiet {
+		log.Printf(format, args...)
 	}
+}
+
+// complete sends the request, streaming the answer to c.stream when set.
+func (c *Client) complete(ctx context.Context, request Request) (*Response, error) {
+	if c.stream == nil {
+		return c.provider.Complete(ctx, request)
+	}
+
+	streamer, ok := c.provider.(StreamingProvider)
+	if !ok {
+		response, err := c.provider.Complete(ctx, request)
+		if err == nil && len(response.Choices) > 0 {
+			fmt.Fprintln(c.stream, strings.TrimSpace(response.Choices[0].Message.Content))
+		}
+		return response, err
+	}
+
+	request.Stream = true
+	// Models often open with blank lines; hold them back so the streamed
+	// output matches the trimmed report
+	started := false
+	endsLine := true
+	response, err := streamer.Stream(ctx, request, func(delta string) {
+		if !started {
+			delta = strings.TrimLeft(delta, " \t\r\n")
+			if delta == "" {
+				return
+			}
+			started = true
+		}
+		fmt.Fprint(c.stream, delta)
+		endsLine = strings.HasSuffix(delta, "\n")
+	})
 
-	return strings.TrimSpace(response.Choices[0].Message.Content), nil
+	// End the streamed line before anything else is logged after it
+	if !endsLine {
+		fmt.Fprintln(c.stream)
+	}
+	return response, err
 }
 
 func (c *Client) formatActivitiesForLLM(activities []types.GitHubActivity) string {
+	return FormatActivities(activities)
+}
+
+// FormatActivities renders activities as the plain-text summary sent to the model.
+func FormatActivities(activities []types.GitHubActivity) string {
 	if len(activities) == 0 {
 		return "No GitHub activity found for the specified period."
 	}
@@ -182,19 +417,28 @@ func (c *Client) formatActivitiesForLLM(activities []types.GitHubActivity) strin
 
 	commits := make([]types.GitHubActivity, 0)
 	prs := make([]types.GitHubActivity, 0)
+	prEvents := make([]types.GitHubActivity, 0)
 	issues := make([]types.GitHubActivity, 0)
 	reviews := make([]types.GitHubActivity, 0)
+	comments := make([]types.GitHubActivity, 0)
+	other := make([]types.GitHubActivity, 0)
 
 	for _, activity := range activities {
 		switch activity.Type {
-		case "commit":
+		case types.KindCommit:
 			commits = append(commits, activity)
-		case "pull_request":
+		case types.KindPullRequest:
 			prs = append(prs, activity)
-		case "issue":
+		case types.KindPullRequestEvent:
+			prEvents = append(prEvents, activity)
+		case types.KindIssue:
 			issues = append(issues, activity)
-		case "review":
+		case types.KindReview:
 			reviews = append(reviews, activity)
+		case types.KindComment:
+			comments = append(comments, activity)
+		default:
+			other = append(other, activity)
 		}
 	}
 
@@ -215,14 +459,9 @@ func (c *Client) formatActivitiesForLLM(activities []types.GitHubActivity) strin
 	}
 
 	// Format pull requests
-	if len(prs) > 0 {
+	if len(prs) > 0 || len(prEvents) > 0 {
 		builder.WriteString("PULL REQUESTS:\n")
-		for _, pr := range prs {
-			builder.WriteString(fmt.Sprintf("- [%s] %s\n", pr.Repository, pr.Title))
-			if pr.Description != "" && len(pr.Description) < 200 {
-				builder.WriteString(fmt.Sprintf("  Description: %s\n", strings.TrimSpace(pr.Description)))
-			}
-		}
+		formatPullRequests(&builder, prs, prEvents)
 		builder.WriteString("\n")
 	}
 
@@ -242,7 +481,40 @@ func (c *Client) formatActivitiesForLLM(activities []types.GitHubActivity) strin
 	if len(reviews) > 0 {
 		builder.WriteString("CODE REVIEWS:\n")
 		for _, review := range reviews {
-			builder.WriteString(fmt.Sprintf("- [%s] %s\n", review.Repository, review.Title))
+			builder.WriteString(fmt.Sprintf("- [%s] %s%s\n", review.Repository, review.Title, formatReviewOutcome(review)))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Format comments, grouped under the issue or pull request they were left on
+	if len(comments) > 0 {
+		builder.WriteString("COMMENTS:\n")
+		var order []string
+		byParent := make(map[string][]types.GitHubActivity)
+		for _, comment := range comments {
+			key := comment.Repository + " " + comment.Title
+			if _, ok := byParent[key]; !ok {
+				order = append(order, key)
+			}
+			byParent[key] = append(byParent[key], comment)
+		}
+		for _, key := range order {
+			thread := byParent[key]
+			builder.WriteString(fmt.Sprintf("- [%s] %s\n", thread[0].Repository, thread[0].Title))
+			for _, comment := range thread {
+				if comment.Description != "" {
+					builder.WriteString(fmt.Sprintf("  > %s\n", comment.Description))
+				}
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	// Format anything without a dedicated section, such as releases
+	if len(other) > 0 {
+		builder.WriteString("OTHER ACTIVITY:\n")
+		for _, activity := range other {
+			builder.WriteString(fmt.Sprintf("- [%s] %s\n", activity.Repository, activity.Title))
 		}
 		builder.WriteString("\n")
 	}
@@ -250,42 +522,121 @@ func (c *Client) formatActivitiesForLLM(activities []types.GitHubActivity) strin
 	return builder.String()
 }
 
-// callGitHubModels makes the API call to GitHub Models
-func (c *Client) callGitHubModels(request Request) (*Response, error) {
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
+// pullRequestSummary is everything that happened to one pull request in the window.
+type pullRequestSummary struct {
+	activity types.GitHubActivity
+	opened   bool
+	merged   bool
+	closed   bool
+	notes    []string
+}
 
-	req, err := http.NewRequest("POST", "https://models.github.ai/inference/chat/completions", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// formatPullRequests groups pull requests by what happened to them: merged,
+// opened, still in progress and closed without merging.
+func formatPullRequests(builder *strings.Builder, prs, prEvents []types.GitHubActivity) {
+	var order []string
+	summaries := make(map[string]*pullRequestSummary)
+	summaryFor := func(activity types.GitHubActivity) *pullRequestSummary {
+		key := activity.URL
+		if key == "" {
+			key = activity.Repository + " " + activity.Title
+		}
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &pullRequestSummary{activity: activity}
+			summaries[key] = summary
+			order = append(order, key)
+		}
+		return summary
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	for _, pr := range prs {
+		summary := summaryFor(pr)
+		summary.activity = pr
+		summary.opened = true
+	}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+	for _, event := range prEvents {
+		if event.PullRequestEvent == nil {
+			continue
+		}
+		summary := summaryFor(event)
+		switch event.PullRequestEvent.Action {
+		case types.ActionMerged:
+			summary.merged = true
+		case types.ActionClosed:
+			summary.closed = true
+		case types.ActionReopened:
+			summary.closed = false
+			summary.notes = append(summary.notes, strings.ToLower(event.Description))
+		default:
+			summary.notes = append(summary.notes, strings.ToLower(event.Description))
+		}
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	groups := []struct {
+		label   string
+		matches func(s *pullRequestSummary) bool
+	}{
+		{"Merged", func(s *pullRequestSummary) bool { return s.merged }},
+		{"Opened", func(s *pullRequestSummary) bool { return !s.merged && !s.closed && s.opened }},
+		{"Still in progress", func(s *pullRequestSummary) bool { return !s.merged && !s.closed && !s.opened }},
+		{"Closed without merging", func(s *pullRequestSummary) bool { return !s.merged && s.closed }},
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	for _, group := range groups {
+		var lines []*pullRequestSummary
+		for _, key := range order {
+			if group.matches(summaries[key]) {
+				lines = append(lines, summaries[key])
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("%s:\n", group.label))
+		for _, summary := range lines {
+			pr := summary.activity
+			line := fmt.Sprintf("- [%s] %s", pr.Repository, pr.Title)
+			if len(summary.notes) > 0 {
+				line += " (" + strings.Join(summary.notes, ", ") + ")"
+			}
+			builder.WriteString(line + "\n")
+			if summary.opened && pr.Description != "" && len(pr.Description) < 200 {
+				builder.WriteString(fmt.Sprintf("  Description: %s\n", strings.TrimSpace(pr.Description)))
+			}
+		}
 	}
+}
 
-	var response Response
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+// reviewStateLabels describes review states the way a standup would phrase them.
+var reviewStateLabels = map[string]string{
+	"APPROVED":          "approved",
+	"CHANGES_REQUESTED": "requested changes",
+	"COMMENTED":         "commented",
+	"DISMISSED":         "dismissed",
+}
+
+// formatReviewOutcome renders the state and inline comment count of a review,
+// e.g. " (approved, 2 inline comments)".
+func formatReviewOutcome(review types.GitHubActivity) string {
+	if review.Review == nil {
+		return ""
 	}
 
-	return &response, nil
+	var parts []string
+	if label, ok := reviewStateLabels[review.Review.State]; ok {
+		parts = append(parts, label)
+	}
+	switch comments := review.Review.Comments; {
+	case comments == 1:
+		parts = append(parts, "1 inline comment")
+	case comments > 1:
+		parts = append(parts, fmt.Sprintf("%d inline comments", comments))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
 }
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -63,3 +64,215 @@ func TestFormatActivitiesForLLMEmpty(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expected, result)
 	}
 }
+
+func TestFormatActivitiesForLLMReviewOutcome(t *testing.T) {
+	client := &Client{}
+
+	activities := []types.GitHubActivity{
+		{
+			Type:       "review",
+			Repository: "test/repo",
+			Title:      "Reviewed PR #8: Fix bug",
+			Review:     &types.ReviewDetail{State: "CHANGES_REQUESTED", Comments: 2},
+		},
+		{
+			Type:       "review",
+			Repository: "test/repo",
+			Title:      "Reviewed PR #9: Add docs",
+			Review:     &types.ReviewDetail{State: "APPROVED"},
+		},
+	}
+
+	result := client.formatActivitiesForLLM(activities)
+
+	if !strings.Contains(result, "Reviewed PR #8: Fix bug (requested changes, 2 inline comments)") {
+		t.Errorf("Expected review state and comment count, got %q", result)
+	}
+
+	if !strings.Contains(result, "Reviewed PR #9: Add docs (approved)") {
+		t.Errorf("Expected approved review, got %q", result)
+	}
+}
+
+func TestFormatActivitiesForLLMGroupsPullRequests(t *testing.T) {
+	client := &Client{}
+
+	activities := []types.GitHubActivity{
+		{
+			Type:       "pull_request",
+			Repository: "test/repo",
+			Title:      "PR #1: New feature",
+			URL:        "https://github.com/test/repo/pull/1",
+		},
+		{
+			Type:             "pull_request_event",
+			Repository:       "test/repo",
+			Title:            "PR #2: Old feature",
+			Description:      "Merged",
+			URL:              "https://github.com/test/repo/pull/2",
+			PullRequestEvent: &types.PullRequestEventDetail{Action: types.ActionMerged},
+		},
+		{
+			Type:             "pull_request_event",
+			Repository:       "test/repo",
+			Title:            "PR #3: Refactoring",
+			Description:      "Pushed 2 commits",
+			URL:              "https://github.com/test/repo/pull/3",
+			PullRequestEvent: &types.PullRequestEventDetail{Action: types.ActionPushed, Commits: 2},
+		},
+	}
+
+	result := client.formatActivitiesForLLM(activities)
+
+	merged := strings.Index(result, "Merged:\n- [test/repo] PR #2: Old feature")
+	opened := strings.Index(result, "Opened:\n- [test/repo] PR #1: New feature")
+	inProgress := strings.Index(result, "Still in progress:\n- [test/repo] PR #3: Refactoring (pushed 2 commits)")
+
+	if merged < 0 || opened < 0 || inProgress < 0 {
+		t.Fatalf("Expected merged, opened and in progress groups, got %q", result)
+	}
+
+	if !(merged < opened && opened < inProgress) {
+		t.Errorf("Expected groups in order merged, opened, in progress, got %q", result)
+	}
+}
+
+func TestFormatActivitiesForLLMGroupsComments(t *testing.T) {
+	client := &Client{}
+
+	activities := []types.GitHubActivity{
+		{Type: "comment", Repository: "test/repo", Title: "Commented on issue #4: Crash", Description: "Can reproduce on main"},
+		{Type: "comment", Repository: "test/repo", Title: "Commented on issue #4: Crash", Description: "Fixed in #5"},
+	}
+
+	result := client.formatActivitiesForLLM(activities)
+
+	expected := "COMMENTS:\n- [test/repo] Commented on issue #4: Crash\n  > Can reproduce on main\n  > Fixed in #5\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected comments grouped by thread, got %q", result)
+	}
+}
+
+// fakeProvider records requests and answers them with a fixed reply.
+type fakeProvider struct {
+	defaultModel string
+	reply        string
+	usage        *Usage
+	// model and finishReason are reported with the reply, when set.
+	model        string
+	finishReason string
+	requests     []Request
+}
+
+func (p *fakeProvider) Name() string         { return "fake" }
+func (p *fakeProvider) DefaultModel() string { return p.defaultModel }
+
+func (p *fakeProvider) Complete(ctx context.Context, request Request) (*Response, error) {
+	p.requests = append(p.requests, request)
+	return &Response{
+		Model:   p.model,
+		Choices: []Choice{{Message: Message{Role: "assistant", Content: p.reply}, FinishReason: p.finishReason}},
+		Usage:   p.usage,
+	}, nil
+}
+
+func TestGenerateStandupReport(t *testing.T) {
+	provider := &fakeProvider{reply: "  Shipped the feature.\n"}
+	client := NewClient(provider)
+
+	activities := []types.GitHubActivity{
+		{Type: "commit", Repository: "test/repo", Title: "Fix bug", Description: "Fix bug"},
+	}
+
+	report, err := client.GenerateStandupReport(context.Background(), activities, ReportInfo{}, "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Text != "Shipped the feature." || report.Model != "openai/gpt-4o" {
+		t.Errorf("Expected trimmed report, got %+v", report)
+	}
+
+	request := provider.requests[0]
+	if request.Model != "openai/gpt-4o" {
+		t.Errorf("Expected the prompt's model, got %q", request.Model)
+	}
+	last := request.Messages[len(request.Messages)-1]
+	if !strings.Contains(last.Content, "- [test/repo] Fix bug") || strings.Contains(last.Content, "{{activities}}") {
+		t.Errorf("Expected activities to be substituted, got %q", last.Content)
+	}
+}
+
+// fakeStreamingProvider answers with the reply split into the given chunks.
+type fakeStreamingProvider struct {
+	fakeProvider
+	chunks []string
+}
+
+func (p *fakeStreamingProvider) Stream(ctx context.Context, request Request, onDelta func(string)) (*Response, error) {
+	p.requests = append(p.requests, request)
+	for _, chunk := range p.chunks {
+		onDelta(chunk)
+	}
+	return &Response{Choices: []Choice{{Message: Message{Role: "assistant", Content: strings.Join(p.chunks, "")}}}}, nil
+}
+
+func TestGenerateStandupReportStreams(t *testing.T) {
+	provider := &fakeStreamingProvider{chunks: []string{"\n\n", "Shipped ", "the feature.", "\n"}}
+	client := NewClient(provider)
+	var out strings.Builder
+	client.SetStreamWriter(&out)
+
+	report, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Text != "Shipped the feature." {
+		t.Errorf("Expected the full trimmed report, got %q", report.Text)
+	}
+	if out.String() != "Shipped the feature.\n" {
+		t.Errorf("Expected streamed output without leading blank lines, got %q", out.String())
+	}
+	if !provider.requests[0].Stream {
+		t.Error("Expected a streaming request")
+	}
+}
+
+func TestGenerateStandupReportStreamEndsLine(t *testing.T) {
+	provider := &fakeStreamingProvider{chunks: []string{"Shipped ", "the feature."}}
+	client := NewClient(provider)
+	var out strings.Builder
+	client.SetStreamWriter(&out)
+
+	if _, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != "Shipped the feature.\n" {
+		t.Errorf("Expected the streamed report to end its line, got %q", out.String())
+	}
+}
+
+func TestGenerateStandupReportStreamFallback(t *testing.T) {
+	provider := &fakeProvider{reply: "  Shipped the feature.\n"}
+	client := NewClient(provider)
+	var out strings.Builder
+	client.SetStreamWriter(&out)
+
+	if _, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != "Shipped the feature.\n" {
+		t.Errorf("Expected the complete report to be written, got %q", out.String())
+	}
+}
+
+func TestGenerateStandupReportProviderDefaultModel(t *testing.T) {
+	provider := &fakeProvider{defaultModel: "local-model", reply: "ok"}
+	client := NewClient(provider)
+
+	if _, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if provider.requests[0].Model != "local-model" {
+		t.Errorf("Expected the provider's default model, got %q", provider.requests[0].Model)
+	}
+}
@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// Comparison is the outcome of generating the report with one model.
+type Comparison struct {
+	Model   string
+	Report  *Report
+	Latency time.Duration
+	Err     error
+}
+
+// CompareModels generates the report with each model concurrently, for
+// choosing between models on the same activity. The result keeps the order of
+// models. Reports are never streamed and each model logs a single line when
+// it finishes, since their output would interleave.
+func (c *Client) CompareModels(
+	ctx context.Context,
+	activities []types.GitHubActivity,
+	info ReportInfo,
+	models []string,
+	promptMessages []PromptMessage,
+) []Comparison {
+	quiet := *c
+	quiet.stream = nil
+	quiet.quiet = true
+
+	log.Printf("  Generating the report with %d models...\n", len(models))
+
+	comparisons := make([]Comparison, len(models))
+	var wg sync.WaitGroup
+	for i, model := range models {
+		wg.Add(1)
+		go func(i int, model string) {
+			defer wg.Done()
+
+			start := time.Now()
+			report, err := quiet.GenerateStandupReport(ctx, activities, info, model, promptMessages)
+			latency := time.Since(start)
+			comparisons[i] = Comparison{Model: model, Report: report, Latency: latency, Err: err}
+
+			if err != nil {
+				log.Printf("  ⚠️  %s failed after %s\n", model, latency.Round(100*time.Millisecond))
+			} else {
+				log.Printf("  %s done (%s, %s)\n", model, latency.Round(100*time.Millisecond), formatUsage(report.Usage))
+			}
+		}(i, model)
+	}
+	wg.Wait()
+
+	return comparisons
+}
+
+// WriteComparison prints each model's report as a section headed by the
+// model, its latency and its token usage.
+func WriteComparison(w io.Writer, comparisons []Comparison) error {
+	for i, comparison := range comparisons {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		latency := comparison.Latency.Round(100 * time.Millisecond)
+		var err error
+		if comparison.Err != nil {
+			_, err = fmt.Fprintf(w, "=== %s (failed after %s) ===\nError: %v\n", comparison.Model, latency, comparison.Err)
+		} else {
+			_, err = fmt.Fprintf(w, "=== %s (%s, %s) ===\n%s\n", comparison.Model, latency, formatUsage(comparison.Report.Usage), comparison.Report.Text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatUsage describes token usage, e.g. "1200 prompt + 300 completion tokens".
+func formatUsage(usage Usage) string {
+	if usage == (Usage{}) {
+		return "usage not reported"
+	}
+	return fmt.Sprintf("%d prompt + %d completion tokens", usage.PromptTokens, usage.CompletionTokens)
+}
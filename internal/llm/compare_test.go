@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// modelProvider answers with a reply naming the model, failing for "broken".
+type modelProvider struct {
+	mu     sync.Mutex
+	models []string
+}
+
+func (p *modelProvider) Name() string         { return "fake" }
+func (p *modelProvider) DefaultModel() string { return "" }
+
+func (p *modelProvider) Complete(ctx context.Context, request Request) (*Response, error) {
+	p.mu.Lock()
+	p.models = append(p.models, request.Model)
+	p.mu.Unlock()
+
+	if request.Model == "broken" {
+		return nil, errors.New("model unavailable")
+	}
+	usage := &Usage{PromptTokens: 50, CompletionTokens: 5, TotalTokens: 55}
+	return &Response{Choices: []Choice{{Message: Message{Role: "assistant", Content: "Report by " + request.Model}}}, Usage: usage}, nil
+}
+
+func TestCompareModels(t *testing.T) {
+	provider := &modelProvider{}
+	client := NewClient(provider)
+	var streamed strings.Builder
+	client.SetStreamWriter(&streamed)
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	activities := []types.GitHubActivity{{Type: types.KindCommit, Repository: "a/one", Title: "Fix bug"}}
+	comparisons := client.CompareModels(context.Background(), activities, ReportInfo{}, []string{"model-a", "broken", "model-b"}, nil)
+
+	if len(comparisons) != 3 || len(provider.models) != 3 {
+		t.Fatalf("Expected a call per model, got %v", provider.models)
+	}
+	for i, model := range []string{"model-a", "broken", "model-b"} {
+		if comparisons[i].Model != model {
+			t.Errorf("Expected results in the order of models, got %q at %d", comparisons[i].Model, i)
+		}
+	}
+	if comparisons[0].Report.Text != "Report by model-a" || comparisons[0].Report.Usage.TotalTokens != 55 {
+		t.Errorf("Unexpected comparison: %+v", comparisons[0].Report)
+	}
+	if comparisons[1].Err == nil {
+		t.Error("Expected the broken model to fail")
+	}
+	if streamed.Len() != 0 {
+		t.Errorf("Expected comparisons not to be streamed, got %q", streamed.String())
+	}
+
+	// One line to start, then one per model
+	lines := strings.Split(strings.TrimSpace(logged.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected a progress line per model, got %q", logged.String())
+	}
+	for _, model := range []string{"model-a", "broken", "model-b"} {
+		if !strings.Contains(logged.String(), model) {
+			t.Errorf("Expected a progress line for %s, got %q", model, logged.String())
+		}
+	}
+}
+
+func TestWriteComparison(t *testing.T) {
+	comparisons := []Comparison{
+		{Model: "model-a", Latency: 1234 * time.Millisecond, Report: &Report{Text: "Did things.", Usage: Usage{PromptTokens: 50, CompletionTokens: 5}}},
+		{Model: "model-b", Latency: 2 * time.Second, Report: &Report{Text: "Did other things."}},
+		{Model: "broken", Latency: 300 * time.Millisecond, Err: errors.New("model unavailable")},
+	}
+
+	var out bytes.Buffer
+	if err := WriteComparison(&out, comparisons); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "=== model-a (1.2s, 50 prompt + 5 completion tokens) ===\nDid things.\n\n" +
+		"=== model-b (2s, usage not reported) ===\nDid other things.\n\n" +
+		"=== broken (failed after 300ms) ===\nError: model unavailable\n"
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ollamaTimeout is generous because local models can take minutes to load and answer.
+const ollamaTimeout = 5 * time.Minute
+
+// ollamaProvider talks to Ollama's native chat API.
+type ollamaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type ollamaRequest struct {
+	Model    string        `json:"model"`
+	Messages []Message     `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Format   string        `json:"format,omitempty"`
+	Options  ollamaOptions `json:"options"`
+}
+
+type ollamaOptions struct {
+	Temperature      *float64 `json:"temperature,omitempty"`
+	TopP             *float64 `json:"top_p,omitempty"`
+	NumPredict       int      `json:"num_predict,omitempty"`
+	Seed             *int     `json:"seed,omitempty"`
+	Stop             []string `json:"stop,omitempty"`
+	PresencePenalty  *float64 `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
+}
+
+type ollamaResponse struct {
+	Model           string  `json:"model"`
+	Message         Message `json:"message"`
+	Done            bool    `json:"done"`
+	DoneReason      string  `json:"done_reason"`
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+	// Error is set instead of the message when the server aborts a stream
+	Error string `json:"error"`
+}
+
+func (r ollamaResponse) usage() *Usage {
+	return &Usage{
+		PromptTokens:     r.PromptEvalCount,
+		CompletionTokens: r.EvalCount,
+		TotalTokens:      r.PromptEvalCount + r.EvalCount,
+	}
+}
+
+// response translates the final answer, whose content may have been streamed.
+func (r ollamaResponse) response(content string) *Response {
+	return &Response{
+		Model:   r.Model,
+		Choices: []Choice{{Message: Message{Role: "assistant", Content: content}, FinishReason: r.DoneReason}},
+		Usage:   r.usage(),
+	}
+}
+
+func newOllamaProvider(opts ProviderOptions) *ollamaProvider {
+	baseURL := firstNonEmpty(opts.BaseURL, envOr("OLLAMA_HOST", "http://localhost:11434"))
+	if !strings.Contains(baseURL, "://") {
+		// OLLAMA_HOST is commonly set to a bare host:port
+		baseURL = "http://" + baseURL
+	}
+
+	return &ollamaProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: newHTTPClient(ollamaTimeout, opts.Retry),
+	}
+}
+
+func (p *ollamaProvider) Name() string { return "Ollama" }
+
+func (p *ollamaProvider) DefaultModel() string { return "llama3.1" }
+
+// buildOllamaRequest translates an OpenAI-style request to the chat API,
+// where sampling parameters are options.
+func buildOllamaRequest(request Request) ollamaRequest {
+	body := ollamaRequest{
+		Model:    request.Model,
+		Messages: request.Messages,
+		Options: ollamaOptions{
+			Temperature:      request.Temperature,
+			TopP:             request.TopP,
+			NumPredict:       request.maxTokens(),
+			Seed:             request.Seed,
+			Stop:             request.Stop,
+			PresencePenalty:  request.PresencePenalty,
+			FrequencyPenalty: request.FrequencyPenalty,
+		},
+	}
+	if request.wantsJSON() {
+		body.Format = "json"
+	}
+	return body
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, request Request) (*Response, error) {
+	body := buildOllamaRequest(request)
+
+	var response ollamaResponse
+	if err := postJSON(ctx, p.httpClient, p.baseURL+"/api/chat", nil, body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.response(response.Message.Content), nil
+}
+
+func (p *ollamaProvider) Stream(ctx context.Context, request Request, onDelta func(string)) (*Response, error) {
+	body := buildOllamaRequest(request)
+	body.Stream = true
+
+	// Ollama streams one JSON object per line
+	var content strings.Builder
+	var last ollamaResponse
+	err := postStream(ctx, p.httpClient, p.baseURL+"/api/chat", nil, body, func(line string) (bool, error) {
+		if strings.TrimSpace(line) == "" {
+			return false, nil
+		}
+
+		var chunk ollamaResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return false, fmt.Errorf("failed to unmarshal stream chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return false, fmt.Errorf("API stream failed: %s", chunk.Error)
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			onDelta(chunk.Message.Content)
+		}
+		// The last object carries the counts and why the model stopped
+		last = chunk
+		return chunk.Done, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return last.response(content.String()), nil
+}
@@ -0,0 +1,162 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+const gitHubModelsBaseURL = "https://models.github.ai/inference"
+
+// gitHubModelsHosts are the hosts the GitHub CLI's token may be sent to.
+var gitHubModelsHosts = map[string]bool{
+	"models.github.ai":              true,
+	"models.inference.ai.azure.com": true,
+}
+
+// openAIProvider talks to any OpenAI-compatible chat completions API,
+// including GitHub Models.
+type openAIProvider struct {
+	name         string
+	baseURL      string
+	apiKey       string
+	defaultModel string
+	httpClient   *http.Client
+}
+
+func (p *openAIProvider) Name() string { return p.name }
+
+func (p *openAIProvider) DefaultModel() string { return p.defaultModel }
+
+func (p *openAIProvider) Complete(ctx context.Context, request Request) (*Response, error) {
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", p.apiKey)
+	}
+
+	var response Response
+	if err := postJSON(ctx, p.httpClient, p.baseURL+"/chat/completions", headers, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// streamOptions asks for the usage of a streamed completion, which is sent
+// in a last chunk without choices.
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *Usage `json:"usage"`
+}
+
+func (p *openAIProvider) Stream(ctx context.Context, request Request, onDelta func(string)) (*Response, error) {
+	headers := map[string]string{"Accept": "text/event-stream"}
+	if p.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", p.apiKey)
+	}
+	request.Stream = true
+	request.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	response := &Response{}
+	var content strings.Builder
+	var finishReason string
+	err := postStream(ctx, p.httpClient, p.baseURL+"/chat/completions", headers, request, func(line string) (bool, error) {
+		data, ok := sseData(line)
+		if !ok || data == "" {
+			return false, nil
+		}
+		if data == "[DONE]" {
+			return true, nil
+		}
+
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return false, fmt.Errorf("failed to unmarshal stream chunk: %w", err)
+		}
+		response.Model = firstNonEmpty(chunk.Model, response.Model)
+		if chunk.Usage != nil {
+			response.Usage = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				content.WriteString(choice.Delta.Content)
+				onDelta(choice.Delta.Content)
+			}
+			finishReason = firstNonEmpty(choice.FinishReason, finishReason)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Choices = []Choice{{Message: Message{Role: "assistant", Content: content.String()}, FinishReason: finishReason}}
+	return response, nil
+}
+
+// newGitHubModelsProvider uses the GitHub CLI's token for GitHub Models. The
+// token is only sent to GitHub Models hosts; other endpoints need an API key.
+func newGitHubModelsProvider(opts ProviderOptions) (*openAIProvider, error) {
+	baseURL := strings.TrimSuffix(firstNonEmpty(opts.BaseURL, gitHubModelsBaseURL), "/")
+	if opts.APIKey == "" && !isGitHubModelsURL(baseURL) {
+		return nil, fmt.Errorf("refusing to send the GitHub token to %s, which is not GitHub Models; use --provider openai for other endpoints", baseURL)
+	}
+
+	log.Print("  Checking GitHub token... ")
+
+	token := opts.APIKey
+	if token == "" {
+		host, _ := auth.DefaultHost()
+		token, _ = auth.TokenForHost(host) // check GH_TOKEN, GITHUB_TOKEN, keychain, etc
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("no GitHub token found. Please run 'gh auth login' to authenticate")
+	}
+	log.Println("Done")
+
+	return &openAIProvider{
+		name:       "GitHub Models API",
+		baseURL:    baseURL,
+		apiKey:     token,
+		httpClient: newHTTPClient(defaultTimeout, opts.Retry),
+	}, nil
+}
+
+// isGitHubModelsURL reports whether rawURL points at GitHub Models over HTTPS.
+func isGitHubModelsURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && parsed.Scheme == "https" && gitHubModelsHosts[parsed.Hostname()]
+}
+
+// newOpenAIProvider configures an OpenAI-compatible endpoint such as OpenAI,
+// Azure OpenAI's v1 API or a self-hosted gateway.
+func newOpenAIProvider(opts ProviderOptions) (*openAIProvider, error) {
+	apiKey := firstNonEmpty(opts.APIKey, envOr("OPENAI_API_KEY", ""))
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found for the OpenAI-compatible provider. Please set OPENAI_API_KEY")
+	}
+
+	return &openAIProvider{
+		name:         "OpenAI-compatible API",
+		baseURL:      strings.TrimSuffix(firstNonEmpty(opts.BaseURL, envOr("OPENAI_BASE_URL", "https://api.openai.com/v1")), "/"),
+		apiKey:       apiKey,
+		defaultModel: "gpt-4o",
+		httpClient:   newHTTPClient(defaultTimeout, opts.Retry),
+	}, nil
+}
@@ -0,0 +1,168 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// modelCapabilities records the parameters a model rejects, so that requests
+// leave them out instead of failing.
+type modelCapabilities struct {
+	NoTemperature bool
+	NoTopP        bool
+	NoPenalties   bool
+	// MaxCompletionTokens means the model takes max_completion_tokens
+	// instead of max_tokens.
+	MaxCompletionTokens bool
+}
+
+// reasoningModel describes OpenAI's reasoning models, which only run with
+// their default sampling.
+var reasoningModel = modelCapabilities{
+	NoTemperature:       true,
+	NoTopP:              true,
+	NoPenalties:         true,
+	MaxCompletionTokens: true,
+}
+
+// modelCapabilityMap maps model names (lowercase, without the publisher) to
+// what they reject. Models not listed accept every parameter.
+var modelCapabilityMap = map[string]modelCapabilities{
+	"gpt-5":      reasoningModel,
+	"gpt-5-mini": reasoningModel,
+	"gpt-5-nano": reasoningModel,
+	"o1":         reasoningModel,
+	"o1-mini":    reasoningModel,
+	"o3":         reasoningModel,
+	"o3-mini":    reasoningModel,
+	"o4-mini":    reasoningModel,
+	// Add other models here as needed
+}
+
+// getModelCapabilities returns what model rejects. Matching is
+// case-insensitive and ignores a publisher prefix such as "openai/".
+func getModelCapabilities(model string) modelCapabilities {
+	model = strings.ToLower(model)
+	if caps, ok := modelCapabilityMap[model]; ok {
+		return caps
+	}
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		return modelCapabilityMap[model[i+1:]]
+	}
+	return modelCapabilities{}
+}
+
+// merge returns p with the parameters set in overrides replaced.
+func (p ModelParameters) merge(overrides ModelParameters) ModelParameters {
+	if overrides.Temperature != nil {
+		p.Temperature = overrides.Temperature
+	}
+	if overrides.TopP != nil {
+		p.TopP = overrides.TopP
+	}
+	if overrides.MaxTokens != 0 {
+		p.MaxTokens = overrides.MaxTokens
+	}
+	if overrides.Seed != nil {
+		p.Seed = overrides.Seed
+	}
+	if overrides.Stop != nil {
+		p.Stop = overrides.Stop
+	}
+	if overrides.PresencePenalty != nil {
+		p.PresencePenalty = overrides.PresencePenalty
+	}
+	if overrides.FrequencyPenalty != nil {
+		p.FrequencyPenalty = overrides.FrequencyPenalty
+	}
+	if overrides.ResponseFormat != "" {
+		p.ResponseFormat = overrides.ResponseFormat
+	}
+	return p
+}
+
+// parameterRanges are the valid values of the numeric model parameters, by
+// their name in prompt files.
+var parameterRanges = []struct {
+	key      string
+	min, max float64
+}{
+	{"temperature", 0, 2},
+	{"topP", 0, 1},
+	{"maxTokens", 1, math.MaxInt32},
+	{"presencePenalty", -2, 2},
+	{"frequencyPenalty", -2, 2},
+}
+
+// Validate checks that the parameters set in p are in range, as prompt files
+// are checked when they are loaded.
+func (p ModelParameters) Validate() error {
+	values := map[string]*float64{
+		"temperature":      p.Temperature,
+		"topP":             p.TopP,
+		"presencePenalty":  p.PresencePenalty,
+		"frequencyPenalty": p.FrequencyPenalty,
+	}
+	if p.MaxTokens != 0 {
+		maxTokens := float64(p.MaxTokens)
+		values["maxTokens"] = &maxTokens
+	}
+
+	for _, r := range parameterRanges {
+		if value := values[r.key]; value != nil && (*value < r.min || *value > r.max) {
+			return fmt.Errorf("%s must be a number between %g and %g", r.key, r.min, r.max)
+		}
+	}
+	if p.ResponseFormat != "" && !slices.Contains(ResponseFormats, p.ResponseFormat) {
+		return fmt.Errorf("unknown responseFormat %q (expected %s)", p.ResponseFormat, strings.Join(ResponseFormats, " or "))
+	}
+	return nil
+}
+
+// newRequest builds a request for model, leaving out the parameters the
+// model rejects.
+func newRequest(model string, messages []Message, params ModelParameters) Request {
+	caps := getModelCapabilities(model)
+	request := Request{
+		Messages: messages,
+		Model:    model,
+		Seed:     params.Seed,
+		Stop:     params.Stop,
+	}
+
+	if !caps.NoTemperature {
+		request.Temperature = params.Temperature
+	}
+	if !caps.NoTopP {
+		request.TopP = params.TopP
+	}
+	if !caps.NoPenalties {
+		request.PresencePenalty = params.PresencePenalty
+		request.FrequencyPenalty = params.FrequencyPenalty
+	}
+	if caps.MaxCompletionTokens {
+		request.MaxCompletionTokens = params.MaxTokens
+	} else {
+		request.MaxTokens = params.MaxTokens
+	}
+	if params.ResponseFormat != "" {
+		request.ResponseFormat = &ResponseFormat{Type: params.ResponseFormat}
+	}
+
+	return request
+}
+
+// maxTokens returns the request's output limit, whichever field carries it.
+func (r Request) maxTokens() int {
+	if r.MaxCompletionTokens != 0 {
+		return r.MaxCompletionTokens
+	}
+	return r.MaxTokens
+}
+
+// wantsJSON reports whether the request asks for a JSON object.
+func (r Request) wantsJSON() bool {
+	return r.ResponseFormat != nil && r.ResponseFormat.Type == "json_object"
+}
@@ -0,0 +1,46 @@
+package llm
+
+import "testing"
+
+func TestNewRequestOmitsRejectedParameters(t *testing.T) {
+	seed := 7
+	params := ModelParameters{
+		Temperature:     floatPtr(0.2),
+		TopP:            floatPtr(0.9),
+		MaxTokens:       300,
+		Seed:            &seed,
+		PresencePenalty: floatPtr(0.5),
+		ResponseFormat:  "json_object",
+	}
+
+	request := newRequest("openai/gpt-4o", nil, params)
+	if request.Temperature == nil || request.TopP == nil || request.MaxTokens != 300 || request.PresencePenalty == nil || *request.PresencePenalty != 0.5 {
+		t.Errorf("Expected every parameter for gpt-4o, got %+v", request)
+	}
+	if request.ResponseFormat == nil || request.ResponseFormat.Type != "json_object" {
+		t.Errorf("Expected a JSON response format, got %+v", request.ResponseFormat)
+	}
+
+	for _, model := range []string{"openai/gpt-5", "GPT-5-mini", "o3-mini"} {
+		request := newRequest(model, nil, params)
+		if request.Temperature != nil || request.TopP != nil || request.PresencePenalty != nil {
+			t.Errorf("Expected sampling parameters to be omitted for %s, got %+v", model, request)
+		}
+		if request.MaxTokens != 0 || request.MaxCompletionTokens != 300 || request.Seed == nil {
+			t.Errorf("Expected max_completion_tokens and the seed for %s, got %+v", model, request)
+		}
+	}
+}
+
+func TestModelParametersMerge(t *testing.T) {
+	prompt := ModelParameters{Temperature: floatPtr(0.2), MaxTokens: 300, Stop: []string{"END"}}
+	prompt.PresencePenalty = floatPtr(0.5)
+	merged := prompt.merge(ModelParameters{Temperature: floatPtr(0), PresencePenalty: floatPtr(0), ResponseFormat: "text"})
+
+	if *merged.Temperature != 0 || *merged.PresencePenalty != 0 {
+		t.Errorf("Expected explicit zeros to override, got %v and %v", *merged.Temperature, *merged.PresencePenalty)
+	}
+	if merged.MaxTokens != 300 || len(merged.Stop) != 1 || merged.ResponseFormat != "text" {
+		t.Errorf("Expected unset overrides to keep the prompt's parameters, got %+v", merged)
+	}
+}
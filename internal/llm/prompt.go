@@ -0,0 +1,233 @@
+package llm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ghconfig "github.com/cli/go-gh/v2/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// PromptFileName is the name under which prompt files are discovered.
+const PromptFileName = "standup.prompt.yml"
+
+// promptRoles are the message roles accepted in prompt files.
+var promptRoles = map[string]bool{"system": true, "user": true, "assistant": true}
+
+// ResponseFormats lists the values accepted for modelParameters.responseFormat.
+var ResponseFormats = []string{"text", "json_object"}
+
+// LoadPromptConfig loads the prompt configuration from path. An empty path
+// uses the first prompt file found by FindPromptFile, or the built-in prompt
+// when there is none.
+func LoadPromptConfig(path string) (*PromptConfig, error) {
+	if path == "" {
+		found, err := FindPromptFile()
+		if err != nil {
+			return nil, err
+		}
+		if found == "" {
+			return loadPromptConfig()
+		}
+		path = found
+	}
+
+	log.Printf("  Loading prompt configuration from %s... ", path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	config, err := parsePromptFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	config.Path = path
+	log.Println("Done")
+
+	return config, nil
+}
+
+// FindPromptFile looks for .github/standup.prompt.yml in the current
+// repository, then standup.prompt.yml in the gh config directory. It
+// returns an empty path when neither exists.
+func FindPromptFile() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	candidates := []string{}
+	if root := FindRepoRoot(wd); root != "" {
+		candidates = append(candidates, filepath.Join(root, ".github", PromptFileName))
+	}
+	candidates = append(candidates, UserPromptPath())
+
+	for _, candidate := range candidates {
+		_, err := os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to check prompt file: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// UserPromptPath returns the path of the user's prompt file, next to the
+// gh-standup configuration in the gh config directory.
+func UserPromptPath() string {
+	return filepath.Join(ghconfig.ConfigDir(), PromptFileName)
+}
+
+// FindRepoRoot returns the closest directory at or above dir containing .git,
+// or an empty string outside a repository.
+func FindRepoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func loadPromptConfig() (*PromptConfig, error) {
+	return parsePromptConfig(standupPromptYAML)
+}
+
+func parsePromptConfig(data []byte) (*PromptConfig, error) {
+	var config PromptConfig
+	err := yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt configuration: %w", err)
+	}
+	return &config, nil
+}
+
+// parsePromptFile parses and validates a user-supplied prompt file. Errors
+// name the file and the offending line.
+func parsePromptFile(name string, data []byte) (*PromptConfig, error) {
+	// Reject unknown fields so that typos such as "modelParameter" do not go unnoticed
+	var config PromptConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid prompt file %s: the file is empty", name)
+		}
+		return nil, fmt.Errorf("invalid prompt file %s: %w", name, err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid prompt file %s: %w", name, err)
+	}
+	if err := validatePromptNode(root.Content[0]); err != nil {
+		return nil, fmt.Errorf("invalid prompt file %s: %w", name, err)
+	}
+
+	return &config, nil
+}
+
+// validatePromptNode checks what the YAML decoder cannot: that there are
+// messages, that they have known roles and valid templates, and that model
+// parameters are in range.
+func validatePromptNode(doc *yaml.Node) error {
+	messages := mappingValue(doc, "messages")
+	if messages == nil || len(messages.Content) == 0 {
+		return fmt.Errorf("line %d: at least one message is required", doc.Line)
+	}
+
+	for _, message := range messages.Content {
+		role := mappingValue(message, "role")
+		if role == nil {
+			return fmt.Errorf("line %d: message has no role", message.Line)
+		}
+		if !promptRoles[role.Value] {
+			return fmt.Errorf("line %d: unknown role %q (expected system, user or assistant)", role.Line, role.Value)
+		}
+		content := mappingValue(message, "content")
+		if content == nil || content.Value == "" {
+			return fmt.Errorf("line %d: message has no content", message.Line)
+		}
+		if err := validatePromptTemplate(content.Value); err != nil {
+			return fmt.Errorf("line %d: %w", content.Line, err)
+		}
+	}
+
+	if params := mappingValue(doc, "modelParameters"); params != nil {
+		for _, r := range parameterRanges {
+			node := mappingValue(params, r.key)
+			if node == nil {
+				continue
+			}
+			var value float64
+			if err := node.Decode(&value); err != nil || value < r.min || value > r.max {
+				return fmt.Errorf("line %d: %s must be a number between %g and %g", node.Line, r.key, r.min, r.max)
+			}
+		}
+		if format := mappingValue(params, "responseFormat"); format != nil && !slices.Contains(ResponseFormats, format.Value) {
+			return fmt.Errorf("line %d: unknown responseFormat %q (expected %s)", format.Line, format.Value, strings.Join(ResponseFormats, " or "))
+		}
+	}
+
+	return nil
+}
+
+// Evaluator checks a report generated from a prompt's test data in
+// `gh standup eval`. Exactly one kind of check is set.
+type Evaluator struct {
+	Name string `yaml:"name"`
+	// String compares the report with fixed text.
+	String *StringEvaluator `yaml:"string,omitempty"`
+	// Regex must match somewhere in the report.
+	Regex string `yaml:"regex,omitempty"`
+	// MaxLength is the most characters the report may have.
+	MaxLength int `yaml:"maxLength,omitempty"`
+	// Uses names a built-in check, such as no-markdown-headers.
+	Uses string `yaml:"uses,omitempty"`
+	// LLM asks a model to judge the report.
+	LLM *LLMEvaluator `yaml:"llm,omitempty"`
+}
+
+// StringEvaluator passes when every field that is set holds for the report.
+type StringEvaluator struct {
+	Contains    string `yaml:"contains,omitempty"`
+	NotContains string `yaml:"notContains,omitempty"`
+	StartsWith  string `yaml:"startsWith,omitempty"`
+	EndsWith    string `yaml:"endsWith,omitempty"`
+	Equals      string `yaml:"equals,omitempty"`
+}
+
+// LLMEvaluator has a model grade the report against a rubric.
+type LLMEvaluator struct {
+	// ModelID is the judge model; empty uses the judge model chosen for the run.
+	ModelID string `yaml:"modelId,omitempty"`
+	Rubric  string `yaml:"rubric"`
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
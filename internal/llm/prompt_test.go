@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPromptFile = `name: Team standup
+model: openai/gpt-4o-mini
+modelParameters:
+  temperature: 0.2
+messages:
+  - role: system
+    content: Be brief
+  - role: user
+    content: "Summarize: {{activities}}"
+`
+
+func TestParsePromptFile(t *testing.T) {
+	config, err := parsePromptFile("team.prompt.yml", []byte(testPromptFile))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Model != "openai/gpt-4o-mini" || *config.ModelParameters.Temperature != 0.2 || len(config.Messages) != 2 {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+}
+
+func TestParsePromptFileBuiltIn(t *testing.T) {
+	if _, err := parsePromptFile(PromptFileName, standupPromptYAML); err != nil {
+		t.Errorf("Expected the built-in prompt to be a valid prompt file: %v", err)
+	}
+}
+
+func TestParsePromptFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", "", "the file is empty"},
+		{"unknown field", "name: x\nmodelParameter:\n  temperature: 1\nmessages: []\n", "line 2: field modelParameter not found"},
+		{"wrong type", "messages: hello\n", "line 1"},
+		{"no messages", "name: x\nmessages: []\n", "line 1: at least one message is required"},
+		{"unknown role", "messages:\n  - role: user\n    content: hi\n  - role: bot\n    content: hi\n", "line 4: unknown role \"bot\""},
+		{"missing content", "messages:\n  - role: user\n", "line 2: message has no content"},
+		{"temperature out of range", "modelParameters:\n  temperature: 3\nmessages:\n  - role: user\n    content: hi\n", "line 2: temperature must be"},
+		{"negative maxTokens", "modelParameters:\n  maxTokens: -1\nmessages:\n  - role: user\n    content: hi\n", "line 2: maxTokens must be"},
+		{"unknown responseFormat", "modelParameters:\n  responseFormat: xml\nmessages:\n  - role: user\n    content: hi\n", "line 2: unknown responseFormat \"xml\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePromptFile("bad.prompt.yml", []byte(tt.content))
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if !strings.Contains(err.Error(), "bad.prompt.yml") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error naming the file and %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFindPromptFile(t *testing.T) {
+	repo := t.TempDir()
+	configDir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", configDir)
+	nested := filepath.Join(repo, "cmd", "tool")
+	for _, dir := range []string{filepath.Join(repo, ".git"), filepath.Join(repo, ".github"), nested} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(nested); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindPromptFile()
+	if err != nil || found != "" {
+		t.Fatalf("Expected no prompt file, got %q, %v", found, err)
+	}
+
+	user := filepath.Join(configDir, PromptFileName)
+	if err := os.WriteFile(user, []byte(testPromptFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found, err = FindPromptFile()
+	if err != nil || found != user {
+		t.Errorf("Expected %q, got %q, %v", user, found, err)
+	}
+
+	want := filepath.Join(repo, ".github", PromptFileName)
+	if err := os.WriteFile(want, []byte(testPromptFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found, err = FindPromptFile()
+	if err != nil || found != want {
+		t.Errorf("Expected %q, got %q, %v", want, found, err)
+	}
+}
+
+func TestGenerateStandupReportUsesPromptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.prompt.yml")
+	if err := os.WriteFile(path, []byte(testPromptFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadPromptConfig(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	provider := &fakeProvider{defaultModel: "provider-model", reply: "ok"}
+	client := NewClient(provider)
+	client.SetPromptConfig(config)
+
+	if _, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	request := provider.requests[0]
+	if request.Model != "openai/gpt-4o-mini" || request.Temperature == nil || *request.Temperature != 0.2 {
+		t.Errorf("Expected the prompt file's model and temperature, got %q at %v", request.Model, request.Temperature)
+	}
+	if request.Messages[0].Content != "Be brief" {
+		t.Errorf("Expected the prompt file's messages, got %+v", request.Messages)
+	}
+}
@@ -0,0 +1,196 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gh-standup/internal/retry"
+)
+
+// Provider sends chat completion requests to a model backend. Requests and
+// responses use the OpenAI chat completions shape; providers with a different
+// API translate to and from it.
+type Provider interface {
+	// Name is shown in progress output, e.g. "GitHub Models".
+	Name() string
+	// DefaultModel is used when no model is requested. An empty string defers
+	// to the model named in the prompt configuration.
+	DefaultModel() string
+	Complete(ctx context.Context, request Request) (*Response, error)
+}
+
+// StreamingProvider is implemented by providers that can deliver the answer
+// incrementally. onDelta is called with each chunk of content as it arrives;
+// the returned response holds the assembled answer.
+type StreamingProvider interface {
+	Provider
+	Stream(ctx context.Context, request Request, onDelta func(string)) (*Response, error)
+}
+
+// Provider names accepted by NewProvider.
+const (
+	ProviderGitHub    = "github"
+	ProviderOpenAI    = "openai"
+	ProviderOllama    = "ollama"
+	ProviderAnthropic = "anthropic"
+)
+
+// ProviderNames lists the providers accepted by NewProvider.
+var ProviderNames = []string{ProviderGitHub, ProviderOpenAI, ProviderOllama, ProviderAnthropic}
+
+// ProviderOptions selects and configures a provider. Empty fields fall back
+// to the provider's environment variables and defaults.
+type ProviderOptions struct {
+	Name    string
+	BaseURL string
+	APIKey  string
+	// Retry configures how rate-limited and failed requests are retried.
+	Retry retry.Options
+}
+
+// defaultTimeout bounds how long a hosted model may take to start answering.
+const defaultTimeout = 30 * time.Second
+
+// NewProvider creates the provider named in opts.
+func NewProvider(opts ProviderOptions) (Provider, error) {
+	switch opts.Name {
+	case "", ProviderGitHub:
+		return newGitHubModelsProvider(opts)
+	case ProviderOpenAI:
+		return newOpenAIProvider(opts)
+	case ProviderOllama:
+		return newOllamaProvider(opts), nil
+	case ProviderAnthropic:
+		return newAnthropicProvider(opts)
+	default:
+		return nil, fmt.Errorf("unknown provider %q (available: %s)", opts.Name, strings.Join(ProviderNames, ", "))
+	}
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// envOr returns the environment variable if set, otherwise the fallback.
+func envOr(name, fallback string) string {
+	return firstNonEmpty(os.Getenv(name), fallback)
+}
+
+// postJSON sends body as JSON to url and decodes the JSON response into response.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, response interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	err = json.Unmarshal(respBody, response)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// newHTTPClient returns a client that retries failed requests and gives up
+// on an attempt whose response has not started within timeout. Reading the
+// body is bounded only by the request's context, so that long streamed
+// answers are not cut off.
+func newHTTPClient(timeout time.Duration, retryOpts retry.Options) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = timeout
+	return &http.Client{Transport: retry.NewTransport(transport, retryOpts)}
+}
+
+// postStream sends body as JSON to url and calls onLine for every line of the
+// response body until it returns done or the body ends.
+func postStream(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}, onLine func(line string) (done bool, err error)) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		done, err := onLine(scanner.Text())
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read response stream: %w", err)
+	}
+
+	return nil
+}
+
+// sseData returns the payload of a server-sent events "data:" line.
+func sseData(line string) (string, bool) {
+	if !strings.HasPrefix(line, "data:") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, "data:")), true
+}
@@ -0,0 +1,354 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testRequest = Request{
+	Model: "test-model",
+	Messages: []Message{
+		{Role: "system", Content: "Be brief"},
+		{Role: "user", Content: "Summarize"},
+	},
+	Temperature: floatPtr(0.5),
+	MaxTokens:   500,
+	Stop:        []string{"END"},
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestOpenAIProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Unexpected Authorization header %q", got)
+		}
+
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if request.Model != "test-model" || len(request.Messages) != 2 {
+			t.Errorf("Unexpected request: %+v", request)
+		}
+
+		w.Write([]byte(`{"model":"test-model-1","choices":[{"message":{"role":"assistant","content":"Done things"},"finish_reason":"stop"}],"usage":{"prompt_tokens":20,"completion_tokens":2,"total_tokens":22}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderOpenAI, BaseURL: server.URL + "/v1/", APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := provider.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Choices[0].Message.Content != "Done things" || response.Model != "test-model-1" || response.Choices[0].FinishReason != "stop" {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+	if response.Usage == nil || response.Usage.TotalTokens != 22 {
+		t.Errorf("Expected usage, got %+v", response.Usage)
+	}
+}
+
+func TestOpenAIProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad model", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderOpenAI, BaseURL: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := provider.Complete(context.Background(), testRequest); err == nil {
+		t.Error("Expected error for failed request")
+	}
+}
+
+func TestOpenAIProviderRetriesRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"model":"test-model-1","choices":[{"message":{"role":"assistant","content":"Done things"},"finish_reason":"stop"}],"usage":{"prompt_tokens":20,"completion_tokens":2,"total_tokens":22}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderOpenAI, BaseURL: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := provider.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 2 || response.Choices[0].Message.Content != "Done things" {
+		t.Errorf("Expected success after a retry, got %+v after %d calls", response, calls)
+	}
+}
+
+func TestOllamaProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+
+		var request ollamaRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if request.Stream || *request.Options.Temperature != 0.5 || request.Options.NumPredict != 500 || len(request.Options.Stop) != 1 {
+			t.Errorf("Unexpected request: %+v", request)
+		}
+
+		w.Write([]byte(`{"message":{"role":"assistant","content":"Local summary"},"done":true,"prompt_eval_count":30,"eval_count":7}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderOllama, BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := provider.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Choices[0].Message.Content != "Local summary" {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+	if response.Usage == nil || response.Usage.PromptTokens != 30 || response.Usage.CompletionTokens != 7 {
+		t.Errorf("Expected usage to be translated, got %+v", response.Usage)
+	}
+}
+
+func TestAnthropicProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if r.Header.Get("x-api-key") != "secret" || r.Header.Get("anthropic-version") == "" {
+			t.Errorf("Missing Anthropic headers: %v", r.Header)
+		}
+
+		var request anthropicRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if request.System != "Be brief" {
+			t.Errorf("Expected system prompt to be moved out of messages, got %q", request.System)
+		}
+		if len(request.Messages) != 1 || request.Messages[0].Role != "user" {
+			t.Errorf("Unexpected messages: %+v", request.Messages)
+		}
+		if request.MaxTokens != 500 || len(request.StopSequences) != 1 {
+			t.Errorf("Expected max_tokens and stop_sequences from the request, got %+v", request)
+		}
+
+		w.Write([]byte(`{"model":"claude-test","content":[{"type":"text","text":"Claude "},{"type":"text","text":"summary"}],"stop_reason":"end_turn","usage":{"input_tokens":20,"output_tokens":5}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderAnthropic, BaseURL: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response, err := provider.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response.Choices[0].Message.Content != "Claude summary" || response.Model != "claude-test" || response.Choices[0].FinishReason != "stop" {
+		t.Errorf("Unexpected response: %+v", response)
+	}
+	if response.Usage == nil || response.Usage.TotalTokens != 25 {
+		t.Errorf("Expected usage to be translated, got %+v", response.Usage)
+	}
+}
+
+// streamProvider returns the named provider pointed at a server that writes body.
+func streamProvider(t *testing.T, name string, body string, check func(r *http.Request)) StreamingProvider {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check(r)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(ProviderOptions{Name: name, BaseURL: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	streamer, ok := provider.(StreamingProvider)
+	if !ok {
+		t.Fatalf("Provider %q does not support streaming", name)
+	}
+	return streamer
+}
+
+func TestProviderStream(t *testing.T) {
+	tests := []struct {
+		provider string
+		body     string
+	}{
+		{
+			provider: ProviderOpenAI,
+			body: "data: {\"model\":\"test-model-1\",\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n" +
+				"data: {\"model\":\"test-model-1\",\"choices\":[{\"delta\":{\"content\":\"Shipped \"}}]}\n\n" +
+				": keep-alive\n\n" +
+				"data: {\"model\":\"test-model-1\",\"choices\":[{\"delta\":{\"content\":\"the fix\"},\"finish_reason\":\"length\"}]}\n\n" +
+				"data: {\"model\":\"test-model-1\",\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3,\"total_tokens\":15}}\n\n" +
+				"data: [DONE]\n\n",
+		},
+		{
+			provider: ProviderOllama,
+			body: `{"message":{"role":"assistant","content":"Shipped "},"done":false}` + "\n" +
+				`{"message":{"role":"assistant","content":"the fix"},"done":false}` + "\n" +
+				`{"model":"test-model-1","message":{"role":"assistant","content":""},"done":true,"done_reason":"length","prompt_eval_count":12,"eval_count":3}` + "\n",
+		},
+		{
+			provider: ProviderAnthropic,
+			body: "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"model\":\"test-model-1\",\"usage\":{\"input_tokens\":12,\"output_tokens\":1}}}\n\n" +
+				"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Shipped \"}}\n\n" +
+				"event: ping\ndata: {\"type\":\"ping\"}\n\n" +
+				"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"the fix\"}}\n\n" +
+				"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"max_tokens\"},\"usage\":{\"output_tokens\":3}}\n\n" +
+				"event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			provider := streamProvider(t, tt.provider, tt.body, func(r *http.Request) {
+				var request struct {
+					Stream        bool           `json:"stream"`
+					StreamOptions *streamOptions `json:"stream_options"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Fatalf("Failed to decode request: %v", err)
+				}
+				if !request.Stream {
+					t.Error("Expected a streaming request")
+				}
+				if (tt.provider == ProviderOpenAI) != (request.StreamOptions != nil && request.StreamOptions.IncludeUsage) {
+					t.Errorf("Expected stream_options to ask for usage from OpenAI-compatible APIs only, got %+v", request.StreamOptions)
+				}
+			})
+
+			var deltas []string
+			response, err := provider.Stream(context.Background(), testRequest, func(delta string) {
+				deltas = append(deltas, delta)
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(deltas) != 2 || deltas[0] != "Shipped " || deltas[1] != "the fix" {
+				t.Errorf("Unexpected deltas: %q", deltas)
+			}
+			if response.Choices[0].Message.Content != "Shipped the fix" {
+				t.Errorf("Expected the assembled answer, got %+v", response)
+			}
+			if response.Model != "test-model-1" || response.Choices[0].FinishReason != FinishReasonLength {
+				t.Errorf("Expected the model and finish reason, got %+v", response)
+			}
+			if response.Usage == nil || response.Usage.PromptTokens != 12 || response.Usage.CompletionTokens != 3 {
+				t.Errorf("Expected the streamed usage, got %+v", response.Usage)
+			}
+		})
+	}
+}
+
+func TestProviderStreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad model", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderOpenAI, BaseURL: server.URL, APIKey: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = provider.(StreamingProvider).Stream(context.Background(), testRequest, func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "bad model") {
+		t.Errorf("Expected the API error, got %v", err)
+	}
+}
+
+func TestAnthropicStreamErrorEvent(t *testing.T) {
+	body := "event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"model\":\"test-model-1\"}}\n\n" +
+		"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Shipped \"}}\n\n" +
+		"event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n"
+	provider := streamProvider(t, ProviderAnthropic, body, func(r *http.Request) {})
+
+	_, err := provider.Stream(context.Background(), testRequest, func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "overloaded_error") {
+		t.Errorf("Expected the aborted stream to fail, got %v", err)
+	}
+}
+
+func TestOllamaStreamErrorLine(t *testing.T) {
+	body := `{"message":{"role":"assistant","content":"Shipped "},"done":false}` + "\n" +
+		`{"error":"model runner has unexpectedly stopped"}` + "\n"
+	provider := streamProvider(t, ProviderOllama, body, func(r *http.Request) {})
+
+	_, err := provider.Stream(context.Background(), testRequest, func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "unexpectedly stopped") {
+		t.Errorf("Expected the aborted stream to fail, got %v", err)
+	}
+}
+
+func TestNewProviderRequiresAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	for _, name := range []string{ProviderOpenAI, ProviderAnthropic} {
+		if _, err := NewProvider(ProviderOptions{Name: name}); err == nil {
+			t.Errorf("Expected error for %s without API key", name)
+		}
+	}
+}
+
+func TestGitHubModelsProviderKeepsTokenOnGitHub(t *testing.T) {
+	t.Setenv("GH_TOKEN", "gh-secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request with Authorization %q", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	for _, baseURL := range []string{server.URL, "http://models.github.ai/inference", "https://models.github.ai.example.com/inference"} {
+		if _, err := NewProvider(ProviderOptions{Name: ProviderGitHub, BaseURL: baseURL}); err == nil {
+			t.Errorf("Expected error for the GitHub token with %s", baseURL)
+		}
+	}
+
+	provider, err := NewProvider(ProviderOptions{Name: ProviderGitHub})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p := provider.(*openAIProvider); p.apiKey != "gh-secret" || p.baseURL != gitHubModelsBaseURL {
+		t.Errorf("Expected the GitHub token for GitHub Models, got %+v", p)
+	}
+}
+
+func TestNewProviderUnknown(t *testing.T) {
+	if _, err := NewProvider(ProviderOptions{Name: "nope"}); err == nil {
+		t.Error("Expected error for unknown provider")
+	}
+}
@@ -0,0 +1,42 @@
+package llm
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// describeResponse summarizes a model call for the progress log, e.g.
+// "1.2s, 50 prompt + 5 completion tokens, gpt-4o-2024-08-06, finish reason stop".
+func describeResponse(response *Response, latency time.Duration) string {
+	parts := []string{latency.Round(100 * time.Millisecond).String()}
+	if response.Usage != nil {
+		parts = append(parts, formatUsage(*response.Usage))
+	}
+	if response.Model != "" {
+		parts = append(parts, response.Model)
+	}
+	if len(response.Choices) > 0 && response.Choices[0].FinishReason != "" {
+		parts = append(parts, "finish reason "+response.Choices[0].FinishReason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WriteStats prints what generating report took: model calls, latency and
+// token usage.
+func WriteStats(w io.Writer, report *Report) error {
+	usage := "not reported"
+	if report.Usage != (Usage{}) {
+		usage = fmt.Sprintf("%d prompt + %d completion = %d", report.Usage.PromptTokens, report.Usage.CompletionTokens, report.Usage.PromptTokens+report.Usage.CompletionTokens)
+	}
+
+	_, err := fmt.Fprintf(w, "Model:          %s\nModel calls:    %d\nLatency:        %s\nTokens:         %s\nFinish reason:  %s\n",
+		report.Model,
+		report.Calls,
+		report.Latency.Round(100*time.Millisecond),
+		usage,
+		firstNonEmpty(report.FinishReason, "not reported"),
+	)
+	return err
+}
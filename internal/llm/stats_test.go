@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGenerateStandupReportRecordsStats(t *testing.T) {
+	provider := &fakeProvider{
+		reply:        "Shipped the",
+		model:        "openai/gpt-4o-2024-11-20",
+		finishReason: FinishReasonLength,
+		usage:        &Usage{PromptTokens: 40, CompletionTokens: 10, TotalTokens: 50},
+	}
+	client := NewClient(provider)
+
+	report, err := client.GenerateStandupReport(context.Background(), nil, ReportInfo{}, "openai/gpt-4o", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Model != "openai/gpt-4o-2024-11-20" || report.FinishReason != FinishReasonLength {
+		t.Errorf("Expected the reported model and finish reason, got %+v", report)
+	}
+	if report.Calls != 1 || report.Usage.TotalTokens != 50 {
+		t.Errorf("Expected one recorded call, got %+v", report)
+	}
+}
+
+func TestDescribeResponse(t *testing.T) {
+	response := &Response{
+		Model:   "gpt-4o-2024-11-20",
+		Choices: []Choice{{FinishReason: "stop"}},
+		Usage:   &Usage{PromptTokens: 50, CompletionTokens: 5},
+	}
+
+	expected := "1.2s, 50 prompt + 5 completion tokens, gpt-4o-2024-11-20, finish reason stop"
+	if got := describeResponse(response, 1234*time.Millisecond); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := describeResponse(&Response{}, 300*time.Millisecond); got != "300ms" {
+		t.Errorf("Expected only the latency without metadata, got %q", got)
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	report := &Report{
+		Model:        "gpt-4o",
+		Usage:        Usage{PromptTokens: 1200, CompletionTokens: 300},
+		Calls:        3,
+		Latency:      4560 * time.Millisecond,
+		FinishReason: "stop",
+	}
+
+	var out bytes.Buffer
+	if err := WriteStats(&out, report); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "Model:          gpt-4o\n" +
+		"Model calls:    3\n" +
+		"Latency:        4.6s\n" +
+		"Tokens:         1200 prompt + 300 completion = 1500\n" +
+		"Finish reason:  stop\n"
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,143 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gh-standup/internal/types"
+)
+
+// ReportInfo describes the report being generated. It is available to prompt
+// templates alongside the activity.
+type ReportInfo struct {
+	// User is the login the report is for.
+	User string
+	// Repo is the repository filter, if any.
+	Repo      string
+	StartDate time.Time
+	EndDate   time.Time
+	// PreviousStandup is the last report generated for User up to StartDate, if any.
+	PreviousStandup     string
+	PreviousStandupDate time.Time
+}
+
+// PromptData is what prompt message templates are rendered with, e.g.
+// {{.User}}, {{len .Activities}} or {{index .Counts "pull_request"}}.
+// {{activities}} expands to Summary, as it did before prompts were templates.
+type PromptData struct {
+	ReportInfo
+	// Days is the length of the reporting period, rounded to whole days.
+	Days int
+	// Summary is the activity formatted as plain text.
+	Summary string
+	// Activities is the activity for custom loops. It is empty when the
+	// activity was too large for the model and Summary holds condensed notes.
+	Activities []types.GitHubActivity
+	// Counts is the number of activities of each kind, keyed by kind.
+	Counts map[string]int
+}
+
+// RepoActivities is one repository's share of the activity, as returned by
+// the groupByRepo template function.
+type RepoActivities struct {
+	Repository string
+	Activities []types.GitHubActivity
+}
+
+// newPromptData prepares template data for activities summarized as summary.
+func newPromptData(info ReportInfo, activities []types.GitHubActivity, summary string) PromptData {
+	data := PromptData{
+		ReportInfo: info,
+		Summary:    summary,
+		Activities: activities,
+		Counts:     make(map[string]int),
+	}
+	if !info.StartDate.IsZero() && !info.EndDate.IsZero() {
+		data.Days = int(math.Round(info.EndDate.Sub(info.StartDate).Hours() / 24))
+	}
+	for kind, count := range types.CountByKind(activities) {
+		data.Counts[string(kind)] = count
+	}
+	return data
+}
+
+// promptFuncs are the functions available to prompt templates. Functions
+// taking a list or string take it last, so that they work in pipelines such
+// as {{.Title | truncate 80}}.
+func promptFuncs(summary string) template.FuncMap {
+	return template.FuncMap{
+		"activities":  func() string { return summary },
+		"groupByRepo": groupByRepo,
+		"filterKind":  filterKind,
+		"truncate":    truncate,
+		"formatDate":  func(layout string, t time.Time) string { return t.Format(layout) },
+		"join":        func(sep string, items []string) string { return strings.Join(items, sep) },
+	}
+}
+
+// parsePromptTemplate parses a prompt message's content.
+func parsePromptTemplate(content, summary string) (*template.Template, error) {
+	return template.New("prompt").Funcs(promptFuncs(summary)).Option("missingkey=zero").Parse(content)
+}
+
+// renderPrompt renders a prompt message's content with data.
+func renderPrompt(content string, data PromptData) (string, error) {
+	tmpl, err := parsePromptTemplate(content, data.Summary)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// groupByRepo groups activities by repository, in order of first appearance.
+func groupByRepo(activities []types.GitHubActivity) []RepoActivities {
+	var groups []RepoActivities
+	index := make(map[string]int)
+	for _, activity := range activities {
+		i, ok := index[activity.Repository]
+		if !ok {
+			i = len(groups)
+			index[activity.Repository] = i
+			groups = append(groups, RepoActivities{Repository: activity.Repository})
+		}
+		groups[i].Activities = append(groups[i].Activities, activity)
+	}
+	return groups
+}
+
+// filterKind returns the activities of the given kind, e.g. "review".
+func filterKind(kind string, activities []types.GitHubActivity) []types.GitHubActivity {
+	var filtered []types.GitHubActivity
+	for _, activity := range activities {
+		if string(activity.Type) == kind {
+			filtered = append(filtered, activity)
+		}
+	}
+	return filtered
+}
+
+// truncate shortens text to at most max characters, marking the cut with an ellipsis.
+func truncate(max int, text string) string {
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:max-1]) + "…"
+}
+
+// validatePromptTemplate reports syntax errors in a prompt message's content.
+func validatePromptTemplate(content string) error {
+	if _, err := parsePromptTemplate(content, ""); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
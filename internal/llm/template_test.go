@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gh-standup/internal/types"
+)
+
+var templateActivities = []types.GitHubActivity{
+	{Type: types.KindCommit, Repository: "a/one", Title: "Fix the flaky test in the scheduler"},
+	{Type: types.KindReview, Repository: "b/two", Title: "Add caching"},
+	{Type: types.KindCommit, Repository: "a/one", Title: "Bump deps"},
+}
+
+func TestRenderPrompt(t *testing.T) {
+	info := ReportInfo{
+		User:            "octocat",
+		Repo:            "a/one",
+		StartDate:       time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC),
+		PreviousStandup: "Started the scheduler fix.",
+	}
+	data := newPromptData(info, templateActivities, "SUMMARY")
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"legacy activities", "Activity:\n{{activities}}", "Activity:\nSUMMARY"},
+		{"report info", "{{.User}} on {{.Repo}} for {{.Days}} days", "octocat on a/one for 2 days"},
+		{"dates", `{{formatDate "Jan 2" .StartDate}} to {{formatDate "Jan 2" .EndDate}}`, "May 1 to May 3"},
+		{"counts", `{{index .Counts "commit"}} commits, {{.Counts.release}} releases`, "2 commits, 0 releases"},
+		{"previous standup", "{{if .PreviousStandup}}Last time: {{.PreviousStandup}}{{end}}", "Last time: Started the scheduler fix."},
+		{"group by repo", "{{range groupByRepo .Activities}}[{{.Repository}}: {{len .Activities}}]{{end}}", "[a/one: 2][b/two: 1]"},
+		{"filter and truncate", `{{range .Activities | filterKind "commit"}}{{.Title | truncate 12}};{{end}}`, "Fix the fla…;Bump deps;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderPrompt(tt.template, data)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderPromptErrors(t *testing.T) {
+	data := newPromptData(ReportInfo{}, nil, "")
+	if _, err := renderPrompt("{{if}}", data); err == nil {
+		t.Error("Expected parse error")
+	}
+	if _, err := renderPrompt("{{.NoSuchField}}", data); err == nil {
+		t.Error("Expected execution error for unknown field")
+	}
+}
+
+func TestParsePromptFileInvalidTemplate(t *testing.T) {
+	content := "messages:\n  - role: system\n    content: hi\n  - role: user\n    content: \"{{range .Activities}}\"\n"
+	_, err := parsePromptFile("bad.prompt.yml", []byte(content))
+	if err == nil || !strings.Contains(err.Error(), "line 5: invalid template") {
+		t.Errorf("Expected template error on line 5, got %v", err)
+	}
+}
+
+func TestGenerateStandupReportRendersTemplates(t *testing.T) {
+	provider := &fakeProvider{reply: "ok"}
+	client := NewClient(provider)
+	prompt := []PromptMessage{{Role: "user", Content: "Standup for {{.User}}:\n{{activities}}"}}
+
+	if _, err := client.GenerateStandupReport(context.Background(), templateActivities, ReportInfo{User: "octocat"}, "", prompt); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content := provider.requests[0].Messages[0].Content
+	if !strings.HasPrefix(content, "Standup for octocat:\nCOMMITS:\n- [a/one] Fix the flaky test") {
+		t.Errorf("Unexpected prompt: %q", content)
+	}
+}
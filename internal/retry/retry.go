@@ -0,0 +1,202 @@
+// Package retry provides an http.RoundTripper that retries rate-limited and
+// failed requests, shared by the GitHub and model API clients.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults used for zero Options fields.
+const (
+	DefaultMaxAttempts = 4
+	DefaultMaxWait     = 60 * time.Second
+
+	// baseDelay is the first backoff delay when the server gives no hint.
+	baseDelay = time.Second
+	// lowQuota is the remaining rate limit below which each response is logged.
+	lowQuota = 10
+)
+
+// Options configures a Transport.
+type Options struct {
+	// MaxAttempts is the total number of attempts per request, including the
+	// first one. 1 disables retries.
+	MaxAttempts int
+	// MaxWait is the longest the transport waits before a single retry. A
+	// server asking for a longer wait, such as an exhausted hourly rate limit,
+	// fails the request instead.
+	MaxWait time.Duration
+}
+
+// Transport retries requests that failed with a network error, a 5xx status
+// or a rate limit. It honors Retry-After and X-RateLimit-Reset and otherwise
+// backs off exponentially with jitter.
+type Transport struct {
+	base        http.RoundTripper
+	maxAttempts int
+	maxWait     time.Duration
+	// sleep waits for d or until ctx is done; replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewTransport wraps base, or http.DefaultTransport when base is nil.
+func NewTransport(base http.RoundTripper, opts Options) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = DefaultMaxWait
+	}
+
+	return &Transport{
+		base:        base,
+		maxAttempts: opts.MaxAttempts,
+		maxWait:     opts.MaxWait,
+		sleep:       sleep,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A body that cannot be rewound can only be sent once
+	attempts := t.maxAttempts
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err == nil {
+			logQuota(req, resp)
+		}
+		if attempt >= attempts || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := delay(resp, attempt, time.Now())
+		if wait > t.maxWait {
+			log.Printf("  ⚠️  %s asked to wait %s before retrying, giving up\n", req.URL.Host, wait.Round(time.Second))
+			return resp, err
+		}
+
+		reason := "request failed"
+		if err == nil {
+			reason = resp.Status
+			// Let the connection be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("  ⏳ %s: %s, retrying in %s (attempt %d/%d)\n", req.URL.Host, reason, wait.Round(100*time.Millisecond), attempt+1, attempts)
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of a request is worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Cancellation is deliberate; anything else is probably a network blip
+		return req.Context().Err() == nil && !errors.Is(err, context.Canceled)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusForbidden:
+		// GitHub reports both primary and secondary rate limits as 403
+		return resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0"
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt.
+func delay(resp *http.Response, attempt int, now time.Time) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return wait
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				// The reset time has one second resolution
+				if wait := time.Unix(reset, 0).Sub(now) + time.Second; wait > 0 {
+					return wait
+				}
+			}
+		}
+	}
+
+	// Exponential backoff with jitter of up to half the delay
+	backoff := baseDelay << (attempt - 1)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// logQuota warns when a response reports that little of the rate limit is left.
+func logQuota(req *http.Request, resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining >= lowQuota || remaining == 0 {
+		// Exhausted limits are reported by the retry itself
+		return
+	}
+
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = req.URL.Host
+	}
+	resetAt := ""
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		resetAt = fmt.Sprintf(", resets at %s", time.Unix(reset, 0).Format("15:04:05"))
+	}
+	log.Printf("  ⚠️  %d requests left in the %s rate limit%s\n", remaining, resource, resetAt)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,205 @@
+// Package timeframe resolves the period a standup report covers.
+package timeframe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// layouts are the absolute formats accepted, tried in order. Layouts without
+// a zone are in the local time zone.
+var layouts = []struct {
+	layout   string
+	dateOnly bool
+}{
+	{time.RFC3339, false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02T15:04", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02", true},
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var (
+	// agoPattern matches relative expressions such as "2 weeks ago" or "an hour ago".
+	agoPattern = regexp.MustCompile(`^(\d+|a|an)\s+(minute|hour|day|week|month|year)s?\s+ago$`)
+	// shortPattern matches shorthands such as "36h", "3d" or "2w".
+	shortPattern = regexp.MustCompile(`^(\d+)([mhdw])$`)
+	// clockPattern matches a time of day such as "17:00", "9am" or "5:30pm".
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+// ParseSince parses the start of a period. Besides RFC 3339 timestamps and
+// plain dates it accepts "now", "today", "yesterday", weekdays ("friday",
+// "last friday"), relative times ("2 weeks ago", "36h") and days followed by a
+// time of day ("monday 09:00"). Days without a time of day start at midnight.
+func ParseSince(expr string, now time.Time) (time.Time, error) {
+	t, _, err := parse(expr, now)
+	return t, err
+}
+
+// ParseUntil parses the end of a period like ParseSince, except that days
+// without a time of day include the whole day.
+func ParseUntil(expr string, now time.Time) (time.Time, error) {
+	t, dateOnly, err := parse(expr, now)
+	if err != nil || !dateOnly {
+		return t, err
+	}
+	return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// parse resolves expr relative to now, reporting whether it named a day
+// rather than an instant.
+func parse(expr string, now time.Time) (time.Time, bool, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	if text == "" {
+		return time.Time{}, false, fmt.Errorf("empty time expression")
+	}
+
+	// Layouts use an upper-case T and Z, which lowering the expression removed
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l.layout, strings.ToUpper(text), now.Location()); err == nil {
+			return t, l.dateOnly, nil
+		}
+	}
+
+	if text == "now" {
+		return now, false, nil
+	}
+	if t, ok := parseRelative(text, now); ok {
+		return t, false, nil
+	}
+
+	// A day, optionally followed by a time of day
+	dayText, clockText := text, ""
+	if i := strings.LastIndex(text, " "); i >= 0 && clockPattern.MatchString(text[i+1:]) {
+		dayText, clockText = text[:i], text[i+1:]
+	}
+	day, ok := parseDay(dayText, now)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("unrecognized time %q (use a date such as 2006-01-02, an RFC 3339 timestamp, or an expression such as \"yesterday\", \"last friday 17:00\" or \"2 weeks ago\")", expr)
+	}
+	if clockText == "" {
+		return day, true, nil
+	}
+
+	hour, minute, err := parseClock(clockText)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time of day in %q: %w", expr, err)
+	}
+	year, month, date := day.Date()
+	return time.Date(year, month, date, hour, minute, 0, 0, day.Location()), false, nil
+}
+
+// parseRelative parses expressions counting back from now.
+func parseRelative(text string, now time.Time) (time.Time, bool) {
+	if m := shortPattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "m":
+			return now.Add(-time.Duration(n) * time.Minute), true
+		case "h":
+			return now.Add(-time.Duration(n) * time.Hour), true
+		case "d":
+			return now.AddDate(0, 0, -n), true
+		case "w":
+			return now.AddDate(0, 0, -7*n), true
+		}
+	}
+
+	m := agoPattern.FindStringSubmatch(text)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := 1
+	if m[1] != "a" && m[1] != "an" {
+		n, _ = strconv.Atoi(m[1])
+	}
+	switch m[2] {
+	case "minute":
+		return now.Add(-time.Duration(n) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case "day":
+		return now.AddDate(0, 0, -n), true
+	case "week":
+		return now.AddDate(0, 0, -7*n), true
+	case "month":
+		return now.AddDate(0, -n, 0), true
+	default:
+		return now.AddDate(-n, 0, 0), true
+	}
+}
+
+// parseDay returns midnight of the day text names. A bare weekday is the
+// latest such day, today included; "last" excludes today.
+func parseDay(text string, now time.Time) (time.Time, bool) {
+	today := StartOfDay(now)
+	switch text {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	last := false
+	if name, ok := strings.CutPrefix(text, "last "); ok {
+		text, last = name, true
+	}
+	weekday, ok := weekdays[text]
+	if !ok {
+		return time.Time{}, false
+	}
+	back := (int(now.Weekday()) - int(weekday) + 7) % 7
+	if back == 0 && last {
+		back = 7
+	}
+	return today.AddDate(0, 0, -back), true
+}
+
+// parseClock parses a time of day such as "17:00", "9am" or "5:30pm".
+func parseClock(text string) (hour, minute int, err error) {
+	m := clockPattern.FindStringSubmatch(text)
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("hour %d is not between 1 and 12", hour)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, fmt.Errorf("hour %d is not between 0 and 23", hour)
+		}
+	}
+	if minute > 59 {
+		return 0, 0, fmt.Errorf("minute %d is not between 0 and 59", minute)
+	}
+	return hour, minute, nil
+}
+
+// StartOfDay returns midnight at the start of t's day, in t's location.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
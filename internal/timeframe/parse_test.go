@@ -0,0 +1,76 @@
+package timeframe
+
+import (
+	"testing"
+	"time"
+)
+
+// now is Wednesday, 2025-06-11 14:30 in UTC+2.
+var now = time.Date(2025, 6, 11, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 6, day, hour, minute, 0, 0, now.Location())
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"2025-06-02", at(2, 0, 0)},
+		{"2025-06-02 09:15", at(2, 9, 15)},
+		{"2025-06-02T09:15:00Z", time.Date(2025, 6, 2, 9, 15, 0, 0, time.UTC)},
+		{"now", now},
+		{"today", at(11, 0, 0)},
+		{"Yesterday", at(10, 0, 0)},
+		{"yesterday 17:00", at(10, 17, 0)},
+		{"wednesday", at(11, 0, 0)},
+		{"last wednesday", at(4, 0, 0)},
+		{"friday", at(6, 0, 0)},
+		{"last friday 5pm", at(6, 17, 0)},
+		{"monday 09:00", at(9, 9, 0)},
+		{"2 weeks ago", time.Date(2025, 5, 28, 14, 30, 0, 0, now.Location())},
+		{"an hour ago", at(11, 13, 30)},
+		{"3 days ago", at(8, 14, 30)},
+		{"36h", at(10, 2, 30)},
+		{"1w", at(4, 14, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseSince(tt.expr, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseUntilIncludesWholeDays(t *testing.T) {
+	got, err := ParseUntil("yesterday", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := at(11, 0, 0).Add(-time.Nanosecond); !got.Equal(want) {
+		t.Errorf("Expected the end of yesterday, got %s", got)
+	}
+
+	got, err = ParseUntil("yesterday 17:00", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !got.Equal(at(10, 17, 0)) {
+		t.Errorf("Expected the time of day to be kept, got %s", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "someday", "friday 25:00", "monday 13pm", "next friday"} {
+		if _, err := ParseSince(expr, now); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
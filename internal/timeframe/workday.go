@@ -0,0 +1,224 @@
+package timeframe
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultWorkdays is the work week used when none is configured.
+var DefaultWorkdays = []string{"mon", "tue", "wed", "thu", "fri"}
+
+// maxDaysOff bounds the search for the previous workday.
+const maxDaysOff = 366
+
+// Calendar knows which days are worked and when the daily standup is, so that
+// a report can cover everything since the previous workday's standup.
+type Calendar struct {
+	workdays map[time.Weekday]bool
+	// holidays are days off, keyed by "2006-01-02".
+	holidays map[string]bool
+	// standupHour and standupMinute are the time of the daily standup, when hasStandup is set.
+	hasStandup    bool
+	standupHour   int
+	standupMinute int
+}
+
+// NewCalendar builds a calendar from weekday names ("mon" or "monday"), an
+// optional holidays file (an ICS calendar or a YAML list of dates) and an
+// optional standup time such as "09:30". An empty work week is Monday to Friday.
+func NewCalendar(workdays []string, holidaysPath, standupTime string) (*Calendar, error) {
+	if len(workdays) == 0 {
+		workdays = DefaultWorkdays
+	}
+
+	calendar := &Calendar{workdays: make(map[time.Weekday]bool), holidays: make(map[string]bool)}
+	for _, name := range workdays {
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		calendar.workdays[weekday] = true
+	}
+
+	if holidaysPath != "" {
+		holidays, err := LoadHolidays(holidaysPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range holidays {
+			calendar.holidays[day.Format(time.DateOnly)] = true
+		}
+	}
+
+	if standupTime != "" {
+		if !clockPattern.MatchString(strings.ToLower(standupTime)) {
+			return nil, fmt.Errorf("invalid standup time %q (expected a time of day such as 09:30)", standupTime)
+		}
+		hour, minute, err := parseClock(strings.ToLower(standupTime))
+		if err != nil {
+			return nil, fmt.Errorf("invalid standup time %q: %w", standupTime, err)
+		}
+		calendar.hasStandup, calendar.standupHour, calendar.standupMinute = true, hour, minute
+	}
+
+	return calendar, nil
+}
+
+// IsWorkday reports whether day is in the work week and not a holiday.
+func (c *Calendar) IsWorkday(day time.Time) bool {
+	return c.workdays[day.Weekday()] && !c.holidays[day.Format(time.DateOnly)]
+}
+
+// SinceLastWorkday returns when the period reported on at now starts: the
+// standup time on the workday before now's day or, without a standup time,
+// the same time of day as now. On a Monday that is the previous Friday.
+func (c *Calendar) SinceLastWorkday(now time.Time) (time.Time, error) {
+	day := StartOfDay(now)
+	for i := 0; i < maxDaysOff; i++ {
+		day = day.AddDate(0, 0, -1)
+		if !c.IsWorkday(day) {
+			continue
+		}
+
+		hour, minute := now.Hour(), now.Minute()
+		if c.hasStandup {
+			hour, minute = c.standupHour, c.standupMinute
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("no workday found in the year before %s", now.Format(time.DateOnly))
+}
+
+// parseWeekday parses a weekday name or its three-letter abbreviation.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for full, weekday := range weekdays {
+		if name == full || name == full[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// LoadHolidays reads days off from an ICS calendar (.ics), taking the days
+// each event spans, or from a YAML list of dates. Recurring ICS events only
+// count on their first occurrence.
+func LoadHolidays(path string) ([]time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read holidays: %w", err)
+	}
+
+	var holidays []time.Time
+	if strings.EqualFold(filepath.Ext(path), ".ics") {
+		holidays, err = parseICS(data)
+	} else {
+		holidays, err = parseHolidayList(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid holidays file %s: %w", path, err)
+	}
+	return holidays, nil
+}
+
+// parseHolidayList parses a YAML list of dates such as "2025-12-25".
+func parseHolidayList(data []byte) ([]time.Time, error) {
+	var dates []string
+	if err := yaml.Unmarshal(data, &dates); err != nil {
+		return nil, err
+	}
+
+	holidays := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q (expected 2006-01-02)", date)
+		}
+		holidays = append(holidays, day)
+	}
+	return holidays, nil
+}
+
+// parseICS returns the days covered by the events of an iCalendar file.
+func parseICS(data []byte) ([]time.Time, error) {
+	var holidays []time.Time
+	var start, end string
+	inEvent := false
+
+	for _, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters, as in "DTSTART;VALUE=DATE:20251225"
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, end = true, "", ""
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			days, err := icsEventDays(start, end)
+			if err != nil {
+				return nil, err
+			}
+			holidays = append(holidays, days...)
+		case inEvent && name == "DTSTART":
+			start = value
+		case inEvent && name == "DTEND":
+			end = value
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICS splits an iCalendar file into logical lines, joining lines that
+// were folded by starting continuation lines with a space or tab.
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// icsEventDays returns the days from start up to end, which is exclusive for
+// all-day events. Only the date part of timestamps is used.
+func icsEventDays(start, end string) ([]time.Time, error) {
+	if len(start) < 8 {
+		return nil, fmt.Errorf("event has an invalid DTSTART %q", start)
+	}
+	first, err := time.Parse("20060102", start[:8])
+	if err != nil {
+		return nil, fmt.Errorf("event has an invalid DTSTART %q", start)
+	}
+
+	last := first
+	if len(end) >= 8 {
+		if last, err = time.Parse("20060102", end[:8]); err != nil {
+			return nil, fmt.Errorf("event has an invalid DTEND %q", end)
+		}
+		if len(end) == 8 {
+			last = last.AddDate(0, 0, -1)
+		}
+	}
+
+	days := []time.Time{first}
+	for day := first.AddDate(0, 0, 1); !day.After(last) && len(days) < maxDaysOff; day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days, nil
+}
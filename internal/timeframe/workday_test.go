@@ -0,0 +1,118 @@
+package timeframe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSinceLastWorkday(t *testing.T) {
+	calendar, err := NewCalendar(nil, "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// now is a Wednesday; Monday 2025-06-09 covers the weekend
+	monday := time.Date(2025, 6, 9, 9, 45, 0, 0, now.Location())
+	got, err := calendar.SinceLastWorkday(monday)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := at(6, 9, 45); !got.Equal(want) {
+		t.Errorf("Expected Monday to cover since Friday, got %s", got)
+	}
+
+	got, _ = calendar.SinceLastWorkday(now)
+	if want := at(10, 14, 30); !got.Equal(want) {
+		t.Errorf("Expected Wednesday to cover since Tuesday, got %s", got)
+	}
+}
+
+func TestSinceLastWorkdayWithHolidaysAndStandupTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.yml")
+	if err := os.WriteFile(path, []byte("- 2025-06-06\n- 2025-06-10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := NewCalendar([]string{"mon", "Tuesday", "wed", "thu", "fri"}, path, "09:30")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := calendar.SinceLastWorkday(now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := at(9, 9, 30); !got.Equal(want) {
+		t.Errorf("Expected to skip Tuesday's holiday to Monday's standup, got %s", got)
+	}
+}
+
+func TestSinceLastWorkdayCustomWorkWeek(t *testing.T) {
+	calendar, err := NewCalendar([]string{"sun", "mon", "tue", "wed", "thu"}, "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sunday := time.Date(2025, 6, 8, 10, 0, 0, 0, now.Location())
+	got, _ := calendar.SinceLastWorkday(sunday)
+	if want := at(5, 10, 0); !got.Equal(want) {
+		t.Errorf("Expected Sunday to cover since Thursday, got %s", got)
+	}
+}
+
+func TestNewCalendarErrors(t *testing.T) {
+	if _, err := NewCalendar([]string{"funday"}, "", ""); err == nil {
+		t.Error("Expected error for an unknown weekday")
+	}
+	if _, err := NewCalendar(nil, "", "25:00"); err == nil {
+		t.Error("Expected error for an invalid standup time")
+	}
+	if _, err := NewCalendar(nil, "", "soon"); err == nil {
+		t.Error("Expected error for an unparsable standup time")
+	}
+	if _, err := NewCalendar(nil, filepath.Join(t.TempDir(), "missing.yml"), ""); err == nil {
+		t.Error("Expected error for a missing holidays file")
+	}
+}
+
+func TestLoadHolidaysICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Christmas\r\n" +
+		"DTSTART;VALUE=DATE:20251225\r\n" +
+		"DTEND;VALUE=DATE:20251227\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Team offsite with a long\r\n" +
+		"  folded name\r\n" +
+		"DTSTART:20251231T090000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	holidays, err := LoadHolidays(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var dates []string
+	for _, day := range holidays {
+		dates = append(dates, day.Format(time.DateOnly))
+	}
+	if len(dates) != 3 || dates[0] != "2025-12-25" || dates[1] != "2025-12-26" || dates[2] != "2025-12-31" {
+		t.Errorf("Unexpected holidays: %v", dates)
+	}
+}
+
+func TestLoadHolidaysInvalidDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.yml")
+	if err := os.WriteFile(path, []byte("- christmas\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHolidays(path); err == nil {
+		t.Error("Expected error for an invalid date")
+	}
+}
@@ -2,11 +2,125 @@ package types
 
 import "time"
 
+// ActivityKind identifies what kind of activity a GitHubActivity describes.
+type ActivityKind string
+
+const (
+	KindCommit           ActivityKind = "commit"
+	KindPullRequest      ActivityKind = "pull_request"
+	KindPullRequestEvent ActivityKind = "pull_request_event"
+	KindIssue            ActivityKind = "issue"
+	KindReview           ActivityKind = "review"
+	KindComment          ActivityKind = "comment"
+	KindCreate           ActivityKind = "create"
+	KindRelease          ActivityKind = "release"
+)
+
+// Kinds lists all known activity kinds in the order they are reported.
+var Kinds = []ActivityKind{
+	KindCommit,
+	KindPullRequest,
+	KindPullRequestEvent,
+	KindIssue,
+	KindReview,
+	KindComment,
+	KindCreate,
+	KindRelease,
+}
+
+// PullRequestAction is a lifecycle transition of a pull request.
+type PullRequestAction string
+
+const (
+	ActionMerged           PullRequestAction = "merged"
+	ActionClosed           PullRequestAction = "closed"
+	ActionReopened         PullRequestAction = "reopened"
+	ActionReadyForReview   PullRequestAction = "ready_for_review"
+	ActionConvertedToDraft PullRequestAction = "converted_to_draft"
+	ActionPushed           PullRequestAction = "pushed"
+)
+
 type GitHubActivity struct {
-	Type        string    `json:"type"`
-	Repository  string    `json:"repository"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	URL         string    `json:"url"`
-	CreatedAt   time.Time `json:"created_at"`
+	Type        ActivityKind `json:"type"`
+	Repository  string       `json:"repository"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	URL         string       `json:"url"`
+	CreatedAt   time.Time    `json:"created_at"`
+
+	// Number is the issue or pull request number the activity belongs to
+	Number int `json:"number,omitempty"`
+	// State is the current state of the issue or pull request (open, closed, merged)
+	State  string   `json:"state,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	// Author is the login of whoever authored the commit, issue or pull request
+	Author    string `json:"author,omitempty"`
+	SHA       string `json:"sha,omitempty"`
+	Additions int    `json:"additions,omitempty"`
+	Deletions int    `json:"deletions,omitempty"`
+	Merged    bool   `json:"merged,omitempty"`
+	Draft     bool   `json:"draft,omitempty"`
+
+	// Per-kind details; at most the one matching Type is set
+	Review           *ReviewDetail           `json:"review,omitempty"`
+	Comment          *CommentDetail          `json:"comment,omitempty"`
+	PullRequestEvent *PullRequestEventDetail `json:"pull_request_event,omitempty"`
+	Release          *ReleaseDetail          `json:"release,omitempty"`
+}
+
+// ReviewDetail describes a submitted pull request review.
+type ReviewDetail struct {
+	// State is APPROVED, CHANGES_REQUESTED, COMMENTED or DISMISSED
+	State string `json:"state"`
+	// Comments is the number of inline comments left with the review
+	Comments int `json:"comments,omitempty"`
+}
+
+// CommentDetail describes a comment. The activity URL points at the parent
+// issue or pull request; URL points at the comment itself.
+type CommentDetail struct {
+	URL           string `json:"url,omitempty"`
+	OnPullRequest bool   `json:"on_pull_request,omitempty"`
+	// Inline is set for review comments left on a diff
+	Inline bool `json:"inline,omitempty"`
+}
+
+// PullRequestEventDetail describes a pull request lifecycle transition.
+type PullRequestEventDetail struct {
+	Action PullRequestAction `json:"action"`
+	// Commits is the number of commits pushed, for pushed transitions
+	Commits int `json:"commits,omitempty"`
+}
+
+// ReleaseDetail describes a published release.
+type ReleaseDetail struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease,omitempty"`
+}
+
+// CountByKind returns how many activities there are of each kind.
+func CountByKind(activities []GitHubActivity) map[ActivityKind]int {
+	counts := make(map[ActivityKind]int)
+	for _, activity := range activities {
+		counts[activity.Type]++
+	}
+	return counts
+}
+
+// Span returns the earliest and latest creation time of the activities, or
+// zero times when none has one.
+func Span(activities []GitHubActivity) (time.Time, time.Time) {
+	var first, last time.Time
+	for _, activity := range activities {
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || activity.CreatedAt.Before(first) {
+			first = activity.CreatedAt
+		}
+		if activity.CreatedAt.After(last) {
+			last = activity.CreatedAt
+		}
+	}
+	return first, last
 }
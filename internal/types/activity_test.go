@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHubActivityLegacyJSON(t *testing.T) {
+	legacy := `{"type":"pull_request","repository":"test/repo","title":"PR #1: Test","description":"Body","url":"https://github.com/test/repo/pull/1","created_at":"2024-05-02T10:00:00Z"}`
+
+	var activity GitHubActivity
+	if err := json.Unmarshal([]byte(legacy), &activity); err != nil {
+		t.Fatalf("Failed to parse legacy activity: %v", err)
+	}
+	if activity.Type != KindPullRequest {
+		t.Errorf("Expected kind %q, got %q", KindPullRequest, activity.Type)
+	}
+
+	encoded, err := json.Marshal(activity)
+	if err != nil {
+		t.Fatalf("Failed to encode activity: %v", err)
+	}
+	if string(encoded) != legacy {
+		t.Errorf("Expected unset fields to be omitted, got %s", encoded)
+	}
+}
+
+func TestGitHubActivityDetailJSON(t *testing.T) {
+	activity := GitHubActivity{
+		Type:   KindReview,
+		Number: 8,
+		Review: &ReviewDetail{State: "APPROVED", Comments: 2},
+	}
+
+	encoded, err := json.Marshal(activity)
+	if err != nil {
+		t.Fatalf("Failed to encode activity: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"review":{"state":"APPROVED","comments":2}`) {
+		t.Errorf("Expected review detail in JSON, got %s", encoded)
+	}
+}
+
+func TestCountByKind(t *testing.T) {
+	counts := CountByKind([]GitHubActivity{{Type: KindCommit}, {Type: KindCommit}, {Type: KindIssue}})
+
+	if counts[KindCommit] != 2 || counts[KindIssue] != 1 || counts[KindReview] != 0 {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	first := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	last := first.Add(5 * time.Hour)
+	activities := []GitHubActivity{{CreatedAt: last}, {}, {CreatedAt: first}, {CreatedAt: first.Add(time.Hour)}}
+
+	start, end := Span(activities)
+	if !start.Equal(first) || !end.Equal(last) {
+		t.Errorf("Expected %v to %v, got %v to %v", first, last, start, end)
+	}
+
+	if start, end := Span(nil); !start.IsZero() || !end.IsZero() {
+		t.Errorf("Expected zero times without activities, got %v to %v", start, end)
+	}
+}